@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"alien-invasion-cc/engine/analysis"
+	"alien-invasion-cc/engine/types"
+)
+
+var (
+	analyzeOutput string
+	analyzeTop    int
+)
+
+// analyzeCmd computes graph analytics over a map
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze [mapfile]",
+	Short: "Compute graph analytics over a map, to find its structurally critical cities",
+	Long: `Compute graph analytics over a map, to find its structurally critical cities before invading it:
+connected components, degree distribution, diameter, articulation points, bridges,
+betweenness centrality and roads with no way back.
+
+Components, diameter, articulation points, bridges and betweenness follow the roads both ways.
+Betweenness and diameter take a search from every city, a time in cities times roads.
+The maps given by --file are analyzed when no map is given as argument.`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeMapFiles(cmd, args, toComplete)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths := mapFiles
+		if len(args) > 0 {
+			paths = args
+		}
+
+		directions, err := types.GetDirectionProfile(directionProfile)
+		if err != nil {
+			return err
+		}
+
+		world, err := loadMapFiles(cmd.Context(), paths, mapFormat, directions, twoWayRoads, cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+
+		report, err := analysis.Analyze(cmd.Context(), world, analysis.WithTop(analyzeTop))
+		if err != nil {
+			return err
+		}
+
+		return writeReport(cmd.OutOrStdout(), analyzeOutput, report)
+	},
+}
+
+func init() {
+	addMapFlags(analyzeCmd, analyzeCmd.PersistentFlags())
+	analyzeCmd.PersistentFlags().StringVar(&analyzeOutput, "output", "table", "output format: table or json")
+	_ = analyzeCmd.RegisterFlagCompletionFunc("output", completeValues("table", "json"))
+	analyzeCmd.Flags().IntVar(&analyzeTop, "top", analysis.DefaultTop, "number of most central cities reported")
+	rootCmd.AddCommand(analyzeCmd)
+}
+
+// tableWriter is a report written as text tables
+type tableWriter interface {
+	WriteTable(w io.Writer) error
+}
+
+// writeReport writes a report as text tables or as indented JSON
+func writeReport(out io.Writer, output string, report tableWriter) error {
+	switch output {
+	case "table":
+		return report.WriteTable(out)
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	return fmt.Errorf("unknown output format %q, expected table or json", output)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"alien-invasion-cc/engine/analysis"
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_writeReport(t *testing.T) {
+	ctx := context.Background()
+
+	in, err := os.Open("../test_data/test_map.json")
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	world, err := loadMap(ctx, in, mapfile.FormatJSON, types.ClassicDirections, true)
+	require.NoError(t, err)
+
+	report, err := analysis.Analyze(ctx, world)
+	require.NoError(t, err)
+
+	// every road gets its way back
+	require.Zero(t, report.AsymmetricRoads)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, writeReport(out, "json", report))
+	decoded := &analysis.Report{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), decoded))
+	require.Equal(t, report, decoded)
+
+	out.Reset()
+	require.NoError(t, writeReport(out, "table", report))
+	require.Contains(t, out.String(), "Asymmetric roads     0\n")
+
+	require.EqualError(t, writeReport(out, "csv", report), `unknown output format "csv", expected table or json`)
+}
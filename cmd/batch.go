@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"alien-invasion-cc/engine"
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+)
+
+var (
+	batchRuns   int
+	batchOutput string
+)
+
+// batchCmd runs an invasion of a map over consecutive seeds
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Run an invasion of a map over consecutive seeds and summarize the outcomes",
+	Long: `Run an invasion of a map over consecutive seeds and summarize the outcomes:
+the steps, the termination and the cities destroyed by every run, and how the runs ended.
+
+The seeds start at --seed, at a random seed by default; every run is reproduced by run --seed.
+--timeout bounds every run, a run cut short ending as cancelled.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if batchRuns < 1 {
+			return fmt.Errorf("invalid number of runs %d, expected at least 1", batchRuns)
+		}
+
+		directions, err := types.GetDirectionProfile(directionProfile)
+		if err != nil {
+			return err
+		}
+
+		m, err := readMaps(cmd.Context(), mapFiles, mapFormat, cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+
+		firstSeed := seed
+		if !cmd.Flags().Changed("seed") {
+			firstSeed = time.Now().UnixNano()
+		}
+
+		report := &BatchReport{Map: strings.Join(mapFiles, ", ")}
+		for i := 0; i < batchRuns; i++ {
+			run, err := runBatchSimulation(cmd.Context(), m, directions, firstSeed+int64(i))
+			if err != nil {
+				return err
+			}
+			report.add(run)
+		}
+
+		return writeReport(cmd.OutOrStdout(), batchOutput, report)
+	},
+}
+
+func init() {
+	addMapFlags(batchCmd, batchCmd.Flags())
+	addSimulationFlags(batchCmd.Flags())
+	batchCmd.Flags().IntVar(&batchRuns, "runs", 10, "number of runs, each seeded with the next seed")
+	batchCmd.Flags().StringVar(&batchOutput, "output", "table", "output format: table or json")
+	_ = batchCmd.RegisterFlagCompletionFunc("output", completeValues("table", "json"))
+	rootCmd.AddCommand(batchCmd)
+}
+
+// BatchRun Type definition, the outcome of a run of a batch
+type BatchRun struct {
+	// Seed of the run
+	Seed int64 `json:"seed"`
+	// Steps simulated
+	Steps uint `json:"steps"`
+	// Reason the run ended for
+	Termination engine.TerminationReason `json:"termination"`
+	// Number of destroyed cities
+	DestroyedCities int `json:"destroyed_cities"`
+	// Number of cities still standing
+	RemainingCities int `json:"remaining_cities"`
+}
+
+// BatchReport Type definition, the outcomes of the runs of a batch and their summary
+type BatchReport struct {
+	// Map file paths
+	Map string `json:"map"`
+	// Every run, by seed
+	Runs []BatchRun `json:"runs"`
+	// Number of runs by reason they ended for
+	Terminations map[engine.TerminationReason]int `json:"terminations"`
+	// Mean number of steps simulated
+	MeanSteps float64 `json:"mean_steps"`
+	// Mean number of destroyed cities
+	MeanDestroyedCities float64 `json:"mean_destroyed_cities"`
+}
+
+// readMaps reads map files once, to be loaded by every run
+func readMaps(ctx context.Context, paths []string, formatName string, stdin io.Reader) (*mapfile.Map, error) {
+	input, err := openMaps(paths, formatName, stdin)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = input.Close() }()
+
+	reader, err := input.mapReader()
+	if err != nil {
+		return nil, err
+	}
+
+	return mapfile.ReadAll(ctx, reader)
+}
+
+// runBatchSimulation runs the invasion of a map with a seed, the output of the simulation being discarded
+func runBatchSimulation(ctx context.Context, m *mapfile.Map, directions *types.DirectionProfile, seed int64) (*BatchRun, error) {
+	runCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	e := engine.NewEngine(numAliens, maxMoves, nil, io.Discard,
+		engine.WithMapReader(mapfile.NewDocumentReader(m)),
+		engine.WithDirectionProfile(directions),
+		engine.WithAutoReverseRoads(twoWayRoads),
+		engine.WithRoadFights(roadFights),
+		engine.WithSeed(seed),
+	)
+
+	// a run reaching its timeout reports the result reached so far, the batch goes on
+	err := e.Run(runCtx)
+	if err != nil && !(errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil) {
+		return nil, err
+	}
+
+	result, err := e.Result(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BatchRun{
+		Seed:            seed,
+		Steps:           result.Steps,
+		Termination:     result.Termination,
+		DestroyedCities: result.DestroyedCities,
+		RemainingCities: result.RemainingCities,
+	}, nil
+}
+
+// add adds a run to the report, updating the summary
+func (r *BatchReport) add(run *BatchRun) {
+	if r.Terminations == nil {
+		r.Terminations = map[engine.TerminationReason]int{}
+	}
+
+	n := float64(len(r.Runs))
+	r.MeanSteps = (r.MeanSteps*n + float64(run.Steps)) / (n + 1)
+	r.MeanDestroyedCities = (r.MeanDestroyedCities*n + float64(run.DestroyedCities)) / (n + 1)
+	r.Terminations[run.Termination]++
+	r.Runs = append(r.Runs, *run)
+}
+
+// WriteTable writes the runs and their summary as aligned text
+func (r *BatchReport) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "SEED\tSTEPS\tTERMINATION\tDESTROYED\tREMAINING\n")
+	for _, run := range r.Runs {
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%d\t%d\n", run.Seed, run.Steps, string(run.Termination), run.DestroyedCities, run.RemainingCities)
+	}
+	fmt.Fprintf(tw, "\n")
+
+	reasons := make([]string, 0, len(r.Terminations))
+	for reason := range r.Terminations {
+		reasons = append(reasons, string(reason))
+	}
+	sort.Strings(reasons)
+
+	fmt.Fprintf(tw, "Runs\t%d\n", len(r.Runs))
+	for _, reason := range reasons {
+		fmt.Fprintf(tw, "Ended as %s\t%d\n", reason, r.Terminations[engine.TerminationReason(reason)])
+	}
+	fmt.Fprintf(tw, "Mean steps\t%.1f\n", r.MeanSteps)
+	fmt.Fprintf(tw, "Mean destroyed cities\t%.1f\n", r.MeanDestroyedCities)
+
+	return tw.Flush()
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"alien-invasion-cc/engine"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_batch(t *testing.T) {
+	out := executeCommand(t, "batch", "-m", "../test_data/test_map", "-n", "4", "--seed", "7", "--runs", "3", "--output", "json")
+
+	report := &BatchReport{}
+	require.NoError(t, json.Unmarshal([]byte(out), report))
+	require.Len(t, report.Runs, 3)
+
+	runs := 0
+	for i, run := range report.Runs {
+		require.Equal(t, int64(7+i), run.Seed)
+		require.Equal(t, 10, run.DestroyedCities+run.RemainingCities)
+	}
+	for _, count := range report.Terminations {
+		runs += count
+	}
+	require.Equal(t, 3, runs)
+
+	// every run is reproduced by run with its seed
+	single := executeCommand(t, "batch", "-m", "../test_data/test_map", "-n", "4", "--seed", "8", "--runs", "1", "--output", "json")
+	again := &BatchReport{}
+	require.NoError(t, json.Unmarshal([]byte(single), again))
+	require.Equal(t, report.Runs[1], again.Runs[0])
+}
+
+func Test_BatchReport_WriteTable(t *testing.T) {
+	report := &BatchReport{}
+	report.add(&BatchRun{Seed: 1, Steps: 4, Termination: engine.TerminationAllTrapped, DestroyedCities: 2, RemainingCities: 3})
+	report.add(&BatchRun{Seed: 2, Steps: 1, Termination: engine.TerminationStalemate, DestroyedCities: 1, RemainingCities: 4})
+	report.add(&BatchRun{Seed: 3, Steps: 10, Termination: engine.TerminationAllTrapped, DestroyedCities: 3, RemainingCities: 2})
+
+	out := &bytes.Buffer{}
+	require.NoError(t, report.WriteTable(out))
+	require.Equal(t, `SEED  STEPS  TERMINATION  DESTROYED  REMAINING
+1     4      all_trapped  2          3
+2     1      stalemate    1          4
+3     10     all_trapped  3          2
+
+Runs                   3
+Ended as all_trapped   2
+Ended as stalemate     1
+Mean steps             5.0
+Mean destroyed cities  2.0
+`, out.String())
+}
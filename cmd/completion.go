@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+)
+
+// mapFormats lists the values of --format
+var mapFormats = []string{string(mapfile.FormatAuto), string(mapfile.FormatClassic), string(mapfile.FormatJSON), string(mapfile.FormatYAML)}
+
+// directionProfiles lists the values of --directions
+var directionProfiles = profileNames()
+
+// mapExtensions lists the extensions of map files, a classic map often having none, before .gz for compressed maps
+var mapExtensions = map[string]bool{"": true, ".map": true, ".txt": true, ".json": true, ".yaml": true, ".yml": true}
+
+// Completing checks if the arguments request shell completions, the output then being read by the shell
+func Completing(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	switch args[0] {
+	case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "completion":
+		return true
+	}
+	return false
+}
+
+// profileNames retrieves the names of the direction profiles
+func profileNames() []string {
+	names := []string{}
+	for _, p := range types.DirectionProfiles {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// completeValues completes a flag with fixed values
+func completeValues(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeFileExtensions completes a flag with the files having one of the extensions
+func completeFileExtensions(extensions ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return extensions, cobra.ShellCompDirectiveFilterFileExt
+	}
+}
+
+// completeMapFiles completes a map file path with the directories and the map files starting with it
+func completeMapFiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	dir, prefix := filepath.Split(toComplete)
+
+	entries, err := os.ReadDir(filepath.Join(".", dir))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	paths := []string{}
+	directories := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".")) {
+			continue
+		}
+
+		if entry.IsDir() {
+			paths = append(paths, dir+name+"/")
+			directories++
+			continue
+		}
+
+		if mapExtensions[filepath.Ext(strings.TrimSuffix(strings.ToLower(name), mapfile.GzipExtension))] {
+			paths = append(paths, dir+name)
+		}
+	}
+	sort.Strings(paths)
+
+	// a directory is completed without a space, its files being completed next
+	directive := cobra.ShellCompDirectiveNoFileComp
+	if directories > 0 {
+		directive |= cobra.ShellCompDirectiveNoSpace
+	}
+	return paths, directive
+}
+
+// completeCities completes the arguments of a command with the names of the cities of the maps given by --file,
+// the standard input, read by the shell, being left alone
+func completeCities(maxArgs int) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= maxArgs || contains(mapFiles, mapfile.StdinPath) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		directions, err := types.GetDirectionProfile(directionProfile)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		world, err := loadMapFiles(ctx, uniquePaths(mapFiles), mapFormat, directions, twoWayRoads, nil)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		cities, err := world.GetCities(ctx)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		names := []string{}
+		for _, city := range cities {
+			if strings.HasPrefix(city.Name, toComplete) {
+				names = append(names, city.Name)
+			}
+		}
+		sort.Strings(names)
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// uniquePaths removes the paths given again, cobra parsing the flags twice when completing
+func uniquePaths(paths []string) []string {
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, path := range paths {
+		if !seen[path] {
+			seen[path] = true
+			unique = append(unique, path)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_completeMapFiles(t *testing.T) {
+	paths, directive := completeMapFiles(rootCmd, nil, "../test_data/test_map")
+	require.Equal(t, []string{"../test_data/test_map", "../test_data/test_map.json", "../test_data/test_map.yaml", "../test_data/test_map2", "../test_data/test_map_circuit"}, paths)
+	require.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	// directories are completed without a space, their files being completed next
+	paths, directive = completeMapFiles(rootCmd, nil, "../test_data/sc")
+	require.Equal(t, []string{"../test_data/scenarios/"}, paths)
+	require.Equal(t, cobra.ShellCompDirectiveNoFileComp|cobra.ShellCompDirectiveNoSpace, directive)
+
+	_, directive = completeMapFiles(rootCmd, nil, "../missing/")
+	require.Equal(t, cobra.ShellCompDirectiveError, directive)
+}
+
+func Test_completeCities(t *testing.T) {
+	out := executeCommand(t, cobra.ShellCompRequestCmd, "analyze", "path", "-m", "../test_data/test_map", "B")
+	require.Equal(t, []string{"Barcelona", "Berlin", "Brussels", ":4"}, strings.Split(strings.TrimSpace(out), "\n")[:4])
+
+	// the destination completes from the same map, nothing is left to complete after it
+	out = executeCommand(t, cobra.ShellCompRequestCmd, "analyze", "path", "-m", "../test_data/test_map.json", "Paris", "")
+	require.Equal(t, []string{"Berlin", "Brussels", "Le Havre", "Paris", "Warsaw", ":4"}, strings.Split(strings.TrimSpace(out), "\n")[:6])
+	out = executeCommand(t, cobra.ShellCompRequestCmd, "analyze", "path", "-m", "../test_data/test_map", "London", "Rome", "")
+	require.True(t, strings.HasPrefix(out, ":4\n"), out)
+}
+
+func Test_Completing(t *testing.T) {
+	require.True(t, Completing([]string{cobra.ShellCompRequestCmd, "analyze", ""}))
+	require.True(t, Completing([]string{"completion", "bash"}))
+	require.False(t, Completing([]string{"run", "-n", "4"}))
+	require.False(t, Completing(nil))
+}
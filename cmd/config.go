@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	configfile "alien-invasion-cc/config"
+)
+
+// configEnv names the environment variable holding the configuration file path, when --config is not given
+const configEnv = configfile.EnvPrefix + "CONFIG"
+
+var configFile string
+
+// lookupEnv looks up the environment variables the configuration is read from, an empty environment in tests
+var lookupEnv = os.LookupEnv
+
+// configSources holds where the flags not given on the command line got their value from, by flag
+var configSources = map[*pflag.Flag]string{}
+
+// configCmd groups the configuration commands
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+	Long: `Inspect the configuration.
+
+Every flag may be set in a YAML or TOML configuration file given with --config or ALIEN_CONFIG,
+and in an ALIEN_* environment variable. A flag given on the command line takes precedence
+over its environment variable, which takes precedence over the configuration file.
+The flags of a subcommand are held in a mapping or table named after it.`,
+}
+
+// configShowCmd prints the effective configuration of a command
+var configShowCmd = &cobra.Command{
+	Use:   "show [command]...",
+	Short: "Print the effective configuration of a command, the run by default, and where every value comes from",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, _, err := rootCmd.Find(args)
+		if err != nil {
+			return err
+		}
+
+		values, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		sources, err := configure(target, values, lookupEnv)
+		if err != nil {
+			return err
+		}
+
+		return writeConfig(cmd.OutOrStdout(), target, sources)
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "YAML or TOML configuration file, ALIEN_* environment variables and flags taking precedence over its values")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		values, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		_, err = configure(cmd, values, lookupEnv)
+		return err
+	}
+
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// loadConfig reads the configuration file given by --config or ALIEN_CONFIG, if any
+func loadConfig() (configfile.Values, error) {
+	path := configFile
+	if path == "" {
+		path, _ = lookupEnv(configEnv)
+	}
+
+	if path == "" {
+		return configfile.Values{}, nil
+	}
+
+	values, err := configfile.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	walkFlags(rootCmd, func(c *cobra.Command, f *pflag.Flag) {
+		known[flagKey(c, f)] = true
+	})
+
+	for _, key := range values.Keys() {
+		if !known[key] {
+			return nil, fmt.Errorf("%s: unknown configuration key %q", path, key)
+		}
+	}
+
+	return values, nil
+}
+
+// configure sets the flags of a command not given on the command line from the environment, then from the
+// configuration values, retrieves where every flag got its value from
+func configure(cmd *cobra.Command, values configfile.Values, lookupEnv func(string) (string, bool)) (map[*pflag.Flag]string, error) {
+	sources := map[*pflag.Flag]string{}
+
+	var err error
+	visitCommandFlags(cmd, func(owner *cobra.Command, f *pflag.Flag) {
+		if err != nil {
+			return
+		}
+
+		key := flagKey(owner, f)
+		if f.Changed {
+			if source, found := configSources[f]; found {
+				sources[f] = source
+			} else {
+				sources[f] = "flag"
+			}
+			return
+		}
+
+		source := "default"
+		if value, found := lookupEnv(configfile.EnvName(key)); found {
+			source = "env " + configfile.EnvName(key)
+			err = setFlag(f, configfile.Scalar(value), source)
+		} else if value, found := values[key]; found {
+			source = "file"
+			err = setFlag(f, value, source)
+		}
+		sources[f] = source
+	})
+
+	return sources, err
+}
+
+// setFlag sets a flag from the configuration, remembering where its value comes from. A list sets the items of a
+// slice flag as they are, a scalar is split at its commas as on the command line
+func setFlag(f *pflag.Flag, value configfile.Value, source string) error {
+	if slice, ok := f.Value.(pflag.SliceValue); ok {
+		items := value.Items
+		if !value.IsList() {
+			items = []string{}
+			if value.Text != "" {
+				items = strings.Split(value.Text, ",")
+			}
+		}
+
+		err := slice.Replace(items)
+		if err != nil {
+			text := strconv.Quote(value.Text)
+			if value.IsList() {
+				text = value.String()
+			}
+			return fmt.Errorf("%s: invalid value %s for --%s: %v", source, text, f.Name, err)
+		}
+	} else if value.IsList() {
+		return fmt.Errorf("%s: invalid value %s for --%s: a single value is expected", source, value.String(), f.Name)
+	} else if err := f.Value.Set(value.Text); err != nil {
+		return fmt.Errorf("%s: invalid value %q for --%s: %v", source, value.Text, f.Name, err)
+	}
+
+	f.Changed = true
+	configSources[f] = source
+	return nil
+}
+
+// givenOnCommandLine checks if a flag was given on the command line rather than set from the configuration
+func givenOnCommandLine(f *pflag.Flag) bool {
+	if f == nil || !f.Changed {
+		return false
+	}
+
+	_, configured := configSources[f]
+	return !configured
+}
+
+// visitCommandFlags visits the flags a command accepts, with the command defining every flag
+func visitCommandFlags(cmd *cobra.Command, visit func(owner *cobra.Command, f *pflag.Flag)) {
+	seen := map[*pflag.Flag]bool{}
+	for c := cmd; c != nil; c = c.Parent() {
+		c.LocalFlags().VisitAll(func(f *pflag.Flag) {
+			if seen[f] || skipConfig(f) {
+				return
+			}
+
+			// only the persistent flags of the parents apply to the command
+			if c != cmd && c.PersistentFlags().Lookup(f.Name) != f {
+				return
+			}
+
+			seen[f] = true
+			visit(c, f)
+		})
+	}
+}
+
+// walkFlags visits the flags defined by a command and its subcommands
+func walkFlags(cmd *cobra.Command, visit func(owner *cobra.Command, f *pflag.Flag)) {
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		if !skipConfig(f) {
+			visit(cmd, f)
+		}
+	})
+
+	for _, sub := range cmd.Commands() {
+		walkFlags(sub, visit)
+	}
+}
+
+// skipConfig checks if a flag can't be configured: the help and the configuration file itself
+func skipConfig(f *pflag.Flag) bool {
+	return f.Name == "help" || f.Name == "config"
+}
+
+// flagKey retrieves the configuration key of a flag, prefixed with the names of the subcommand defining it,
+// the flags of run being keyed as the flags of the bare root command it is an alias of
+func flagKey(owner *cobra.Command, f *pflag.Flag) string {
+	names := []string{}
+	for c := owner; c != nil && c.HasParent() && c != runCmd; c = c.Parent() {
+		names = append([]string{c.Name()}, names...)
+	}
+	return configfile.Key(strings.Join(names, "."), f.Name)
+}
+
+// writeConfig writes the flags of a command with their value and where it comes from, by key
+func writeConfig(out io.Writer, cmd *cobra.Command, sources map[*pflag.Flag]string) error {
+	type entry struct {
+		key, value, source string
+	}
+
+	entries := []entry{}
+	visitCommandFlags(cmd, func(owner *cobra.Command, f *pflag.Flag) {
+		value := f.Value.String()
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			value = strings.Join(slice.GetSlice(), ",")
+		}
+		entries = append(entries, entry{key: flagKey(owner, f), value: value, source: sources[f]})
+	})
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "KEY\tVALUE\tSOURCE\n")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", e.key, e.value, e.source)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+
+	configfile "alien-invasion-cc/config"
+)
+
+func Test_configure(t *testing.T) {
+	t.Cleanup(func() { configSources = map[*pflag.Flag]string{} })
+
+	var (
+		aliens  uint
+		seed    int64
+		format  string
+		addr    string
+		origins []string
+	)
+
+	root := &cobra.Command{Use: "root"}
+	root.Flags().UintVar(&aliens, "aliens", 5, "")
+	root.Flags().Int64Var(&seed, "seed", 0, "")
+	root.PersistentFlags().StringVar(&format, "format", "auto", "")
+	root.PersistentFlags().StringVar(&configFile, "config", "", "")
+
+	serve := &cobra.Command{Use: "serve"}
+	serve.Flags().StringVar(&addr, "addr", ":8080", "")
+	serve.Flags().StringSliceVar(&origins, "allowed-origins", nil, "")
+	root.AddCommand(serve)
+
+	env := map[string]string{"ALIEN_ALIENS": "7", "ALIEN_SERVE_ALLOWED_ORIGINS": "a,b"}
+	lookupEnv := func(name string) (string, bool) {
+		value, found := env[name]
+		return value, found
+	}
+	values := configfile.Values{"aliens": configfile.Scalar("4"), "seed": configfile.Scalar("1"), "format": configfile.Scalar("json"), "serve.addr": configfile.Scalar(":1")}
+
+	// flag > env > file > default
+	require.NoError(t, root.Flags().Set("seed", "9"))
+	sources, err := configure(root, values, lookupEnv)
+	require.NoError(t, err)
+	require.Equal(t, uint(7), aliens)
+	require.Equal(t, int64(9), seed)
+	require.Equal(t, "json", format)
+	require.Equal(t, map[*pflag.Flag]string{
+		root.Flags().Lookup("aliens"):           "env ALIEN_ALIENS",
+		root.Flags().Lookup("seed"):             "flag",
+		root.PersistentFlags().Lookup("format"): "file",
+	}, sources)
+
+	require.False(t, givenOnCommandLine(root.Flags().Lookup("aliens")))
+	require.True(t, givenOnCommandLine(root.Flags().Lookup("seed")))
+
+	// the flags of a subcommand are prefixed with its name, the persistent flags of its parents apply to it
+	sources, err = configure(serve, values, lookupEnv)
+	require.NoError(t, err)
+	require.Equal(t, ":1", addr)
+	require.Equal(t, []string{"a", "b"}, origins)
+	require.Len(t, sources, 3)
+	require.Equal(t, "file", sources[root.PersistentFlags().Lookup("format")])
+
+	env["ALIEN_ALIENS"] = "many"
+	root.Flags().Lookup("aliens").Changed = false
+	_, err = configure(root, values, lookupEnv)
+	require.EqualError(t, err, `env ALIEN_ALIENS: invalid value "many" for --aliens: strconv.ParseUint: parsing "many": invalid syntax`)
+
+	// the items of a list are kept whole, a comma within one included
+	delete(env, "ALIEN_ALIENS")
+	delete(env, "ALIEN_SERVE_ALLOWED_ORIGINS")
+	values["serve.allowed-origins"] = configfile.List("https://a.example/x,y", "https://b.example")
+	serve.Flags().Lookup("allowed-origins").Changed = false
+	_, err = configure(serve, values, lookupEnv)
+	require.NoError(t, err)
+	require.Equal(t, []string{"https://a.example/x,y", "https://b.example"}, origins)
+
+	values["aliens"] = configfile.List("4")
+	root.Flags().Lookup("aliens").Changed = false
+	_, err = configure(root, values, lookupEnv)
+	require.EqualError(t, err, `file: invalid value ["4"] for --aliens: a single value is expected`)
+}
+
+func Test_loadConfig(t *testing.T) {
+	t.Cleanup(func() { configFile = "" })
+
+	configFile = "../test_data/config/ci.toml"
+	values, err := loadConfig()
+	require.NoError(t, err)
+	require.Equal(t, configfile.Scalar("true"), values["analyze.path.fastest"])
+
+	configFile = filepath.Join(t.TempDir(), "typo.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("serve:\n  adr: :80\n"), 0o644))
+	_, err = loadConfig()
+	require.EqualError(t, err, configFile+`: unknown configuration key "serve.adr"`)
+}
+
+func Test_configShow(t *testing.T) {
+	out, err := executeCommandWithEnv(t, map[string]string{"ALIEN_STEPS": "20"}, "config", "show", "--config", "../test_data/config/ci.yaml")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out, "KEY "), out)
+	require.Regexp(t, `\naliens +4 +file\n`, out)
+	require.Regexp(t, `\nsteps +20 +env ALIEN_STEPS\n`, out)
+	require.Regexp(t, `\ntwo-way +false +default\n`, out)
+
+	out = executeCommand(t, "config", "show", "analyze", "path", "--config", "../test_data/config/ci.yaml")
+	require.Regexp(t, `\nanalyze.output +json +file\n`, out)
+	require.Regexp(t, `\nanalyze.path.fastest +true +file\n`, out)
+}
+
+func Test_flagKey(t *testing.T) {
+	// run is an alias of the bare root command, their flags share their keys
+	require.Equal(t, "aliens", flagKey(runCmd, runCmd.Flags().Lookup("aliens")))
+	require.Equal(t, "aliens", flagKey(rootCmd, rootCmd.Flags().Lookup("aliens")))
+	require.Equal(t, "batch.aliens", flagKey(batchCmd, batchCmd.Flags().Lookup("aliens")))
+	require.Equal(t, "analyze.file", flagKey(analyzeCmd, analyzeCmd.PersistentFlags().Lookup("file")))
+}
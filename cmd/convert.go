@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"alien-invasion-cc/engine/mapfile"
+)
+
+var (
+	convertFrom string
+	convertTo   string
+)
+
+// convertCmd converts a map file between formats
+var convertCmd = &cobra.Command{
+	Use:   "convert <input> <output>",
+	Short: "Convert a map file between the classic, JSON and YAML formats",
+	Long: `Convert a map file between the classic, JSON and YAML formats.
+
+Formats are detected from the file extensions unless --from or --to is given.
+Use "-" as output to write to the standard output, in the classic format by default.
+Attributes, coordinates and metadata are dropped when converting to the classic format.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inPath, outPath := args[0], args[1]
+
+		from, err := mapfile.ParseFormat(convertFrom)
+		if err != nil {
+			return err
+		}
+
+		if from == mapfile.FormatAuto {
+			from = mapfile.FormatFromPath(inPath)
+		}
+
+		to, err := mapfile.ParseFormat(convertTo)
+		if err != nil {
+			return err
+		}
+
+		if to == mapfile.FormatAuto {
+			to = mapfile.FormatFromPath(outPath)
+		}
+
+		if to == mapfile.FormatAuto {
+			to = mapfile.FormatClassic
+		}
+
+		in, err := os.Open(inPath)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = in.Close() }()
+
+		if outPath == "-" {
+			return convertMap(cmd.Context(), in, from, cmd.OutOrStdout(), to)
+		}
+
+		out, err := os.Create(outPath)
+		if err != nil {
+			return err
+		}
+
+		err = convertMap(cmd.Context(), in, from, out, to)
+		if err != nil {
+			_ = out.Close()
+			return err
+		}
+
+		return out.Close()
+	},
+}
+
+func init() {
+	convertCmd.Flags().StringVar(&convertFrom, "from", string(mapfile.FormatAuto), "input format: auto, classic, json or yaml")
+	convertCmd.Flags().StringVar(&convertTo, "to", string(mapfile.FormatAuto), "output format: auto, classic, json or yaml")
+	rootCmd.AddCommand(convertCmd)
+}
+
+// convertMap reads a map in a format and writes it in another
+func convertMap(ctx context.Context, in io.Reader, from mapfile.Format, out io.Writer, to mapfile.Format) error {
+	r, err := mapfile.NewMapReader(in, from)
+	if err != nil {
+		return err
+	}
+
+	m, err := mapfile.ReadAll(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	return mapfile.Encode(out, to, m)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_convertMap(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Case 1: classic to JSON and back", func(t *testing.T) {
+		input := "City1 north=City2 east=City3:2\nCity2 south=City1\nCity3\n"
+
+		json := &bytes.Buffer{}
+		err := convertMap(ctx, strings.NewReader(input), mapfile.FormatAuto, json, mapfile.FormatJSON)
+		require.NoError(t, err)
+		require.Contains(t, json.String(), `"length": 2`)
+
+		classic := &bytes.Buffer{}
+		err = convertMap(ctx, json, mapfile.FormatAuto, classic, mapfile.FormatClassic)
+		require.NoError(t, err)
+		require.Equal(t, input, classic.String())
+	})
+
+	t.Run("Case 2: YAML to JSON", func(t *testing.T) {
+		in, err := os.Open("../test_data/test_map.yaml")
+		require.NoError(t, err)
+		defer func() { _ = in.Close() }()
+
+		expected, err := os.ReadFile("../test_data/test_map.json")
+		require.NoError(t, err)
+
+		out := &bytes.Buffer{}
+		err = convertMap(ctx, in, mapfile.FormatYAML, out, mapfile.FormatJSON)
+		require.NoError(t, err)
+		require.JSONEq(t, string(expected), out.String())
+	})
+
+	t.Run("Case 3: names with spaces can't be converted to classic", func(t *testing.T) {
+		in, err := os.Open("../test_data/test_map.json")
+		require.NoError(t, err)
+		defer func() { _ = in.Close() }()
+
+		err = convertMap(ctx, in, mapfile.FormatAuto, &bytes.Buffer{}, mapfile.FormatClassic)
+		require.ErrorIs(t, err, types.ERR_UNREPRESENTABLE_NAME)
+	})
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+)
+
+// addMapFlags adds the flags selecting and reading the map to a command, shared by the commands loading a map
+func addMapFlags(cmd *cobra.Command, flags *pflag.FlagSet) {
+	flags.VarP(newPathsValue(&mapFiles, "test_data/test_map"), "file", "m", "map file path, - reading the standard input and .gz files being decompressed; repeated to merge several maps into one world")
+	flags.StringVar(&mapFormat, "format", string(mapfile.FormatAuto), "map file format: auto, classic, json or yaml")
+	flags.StringVar(&directionProfile, "directions", types.ClassicDirections.Name, "directions the map may use: classic, compass, layered or free")
+	flags.BoolVar(&twoWayRoads, "two-way", false, "create the way back of roads listed on only one side of the map")
+
+	_ = cmd.RegisterFlagCompletionFunc("file", completeMapFiles)
+	_ = cmd.RegisterFlagCompletionFunc("format", completeValues(mapFormats...))
+	_ = cmd.RegisterFlagCompletionFunc("directions", completeValues(directionProfiles...))
+}
+
+// addSimulationFlags adds the flags setting up a simulation, shared by the commands running one
+func addSimulationFlags(flags *pflag.FlagSet) {
+	flags.UintVarP(&numAliens, "aliens", "n", 5, "number of aliens to be spawned")
+	flags.UintVarP(&maxMoves, "steps", "s", 10000, "number of maximum moves")
+	flags.BoolVar(&roadFights, "road-fights", false, "aliens crossing each other on a road fight")
+	flags.Int64Var(&seed, "seed", 0, "seed of the simulation, the same seed and map always giving the same invasion (random by default)")
+	flags.DurationVar(&timeout, "timeout", 0, "duration after which the run is cancelled, reporting the result reached so far (no timeout by default)")
+}
+
+// addRunFlags adds the flags of a run, the bare root command being an alias of run
+func addRunFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	addMapFlags(cmd, flags)
+	addSimulationFlags(flags)
+
+	flags.StringVarP(&outputMapFile, "output-map", "o", "", "file the world is written to after the invasion, in the classic format")
+	flags.BoolVar(&sortedOutputMap, "output-map-sorted", false, "write the cities of the output map sorted by name instead of in input order")
+	flags.BoolVar(&destroyedInOutputMap, "output-map-destroyed", false, "write the destroyed cities as comments in the output map")
+	flags.BoolVar(&streamMap, "stream", false, "load classic maps line by line, for maps too large to be held in memory")
+	flags.IntVar(&maxLineSize, "max-line-size", mapfile.DefaultMaxLineSize, "length in bytes beyond which a map line is rejected")
+	flags.BoolVar(&showProgress, "progress", false, "report the map loading progress on the standard error, with --stream or --parallel")
+	flags.IntVar(&parallelWorkers, "parallel", 0, "number of goroutines parsing a classic map concurrently, 0 parses it serially")
+	flags.StringVar(&metricsAddr, "metrics-addr", "", "address to serve the metrics of the run on, /metrics in the Prometheus text format and /debug/vars through expvar")
+	flags.BoolVar(&reportFragmentation, "fragmentation", false, "report how the destruction split the map once the invasion is over")
+	flags.BoolVar(&reportPositions, "positions", false, "report the city every alien left stands in once the invasion is over")
+	flags.StringVar(&scenarioFile, "scenario", "", "scenario file describing the whole experiment, the other flags given taking precedence over its values")
+	flags.StringVar(&traceFile, "trace-file", "", "file the spans of the run are written to, as JSON lines")
+	flags.StringVar(&eventsFile, "events-file", "", "file the events of the run are written to, as JSON lines read by replay")
+
+	_ = cmd.RegisterFlagCompletionFunc("output-map", completeMapFiles)
+	_ = cmd.RegisterFlagCompletionFunc("scenario", completeFileExtensions("yaml", "yml"))
+}
+
+// pathsValue Type definition, a flag given once per path, the paths given replacing the default
+type pathsValue struct {
+	paths *[]string
+	given bool
+}
+
+// Generate New pathsValue
+func newPathsValue(paths *[]string, defaults ...string) *pathsValue {
+	*paths = defaults
+	return &pathsValue{paths: paths}
+}
+
+// Set adds a path, the first one replacing the default
+func (v *pathsValue) Set(path string) error {
+	if !v.given {
+		*v.paths = nil
+		v.given = true
+	}
+	*v.paths = append(*v.paths, path)
+	return nil
+}
+
+// Type names the value in the usage
+func (v *pathsValue) Type() string {
+	return "paths"
+}
+
+// String output of pathsValue
+func (v *pathsValue) String() string {
+	return "[" + strings.Join(*v.paths, ",") + "]"
+}
+
+// Append adds a path
+func (v *pathsValue) Append(path string) error {
+	*v.paths = append(*v.paths, path)
+	return nil
+}
+
+// Replace replaces the paths, the next path given replacing them in turn
+func (v *pathsValue) Replace(paths []string) error {
+	*v.paths = paths
+	v.given = false
+	return nil
+}
+
+// GetSlice retrieves the paths
+func (v *pathsValue) GetSlice() []string {
+	return *v.paths
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"alien-invasion-cc/engine/mapfile"
+)
+
+var (
+	generateWidth  int
+	generateHeight int
+	generateFormat string
+)
+
+// generateCmd generates a grid map
+var generateCmd = &cobra.Command{
+	Use:   "generate [output]",
+	Short: "Generate a grid map, every city linked to its four neighbours",
+	Long: `Generate a width x height grid map, every city linked to its four neighbours, the city at
+column x and row y being named City_x_y.
+
+The map is written to the standard output, or to the file given, in the format detected from
+its extension unless --format is given, the classic format by default.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeMapFiles,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if generateWidth < 1 || generateHeight < 1 {
+			return fmt.Errorf("invalid grid %dx%d, the width and height must be at least 1", generateWidth, generateHeight)
+		}
+
+		outPath := "-"
+		if len(args) > 0 {
+			outPath = args[0]
+		}
+
+		format, err := mapfile.ParseFormat(generateFormat)
+		if err != nil {
+			return err
+		}
+
+		if format == mapfile.FormatAuto && outPath != "-" {
+			format = mapfile.FormatFromPath(outPath)
+		}
+
+		if format == mapfile.FormatAuto {
+			format = mapfile.FormatClassic
+		}
+
+		if outPath == "-" {
+			return generateGrid(cmd.Context(), cmd.OutOrStdout(), generateWidth, generateHeight, format)
+		}
+
+		out, err := os.Create(outPath)
+		if err != nil {
+			return err
+		}
+
+		err = generateGrid(cmd.Context(), out, generateWidth, generateHeight, format)
+		if err != nil {
+			_ = out.Close()
+			return err
+		}
+
+		return out.Close()
+	},
+}
+
+func init() {
+	generateCmd.Flags().IntVar(&generateWidth, "width", 10, "number of columns of the grid")
+	generateCmd.Flags().IntVar(&generateHeight, "height", 10, "number of rows of the grid")
+	generateCmd.Flags().StringVar(&generateFormat, "format", string(mapfile.FormatAuto), "output format: auto, classic, json or yaml")
+	_ = generateCmd.RegisterFlagCompletionFunc("format", completeValues(mapFormats...))
+	rootCmd.AddCommand(generateCmd)
+}
+
+// generateGrid writes a grid map in a format
+func generateGrid(ctx context.Context, out io.Writer, width, height int, format mapfile.Format) error {
+	if format == mapfile.FormatClassic {
+		return mapfile.WriteGrid(out, width, height)
+	}
+
+	classic := &bytes.Buffer{}
+	err := mapfile.WriteGrid(classic, width, height)
+	if err != nil {
+		return err
+	}
+
+	return convertMap(ctx, classic, mapfile.FormatClassic, out, format)
+}
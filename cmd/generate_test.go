@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_generateGrid(t *testing.T) {
+	ctx := context.Background()
+
+	for _, format := range []mapfile.Format{mapfile.FormatClassic, mapfile.FormatJSON, mapfile.FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			out := &bytes.Buffer{}
+			require.NoError(t, generateGrid(ctx, out, 3, 2, format))
+
+			world, err := loadMap(ctx, out, format, types.ClassicDirections, false)
+			require.NoError(t, err)
+
+			cities, err := world.GetCities(ctx)
+			require.NoError(t, err)
+			require.Len(t, cities, 6)
+
+			roads, err := world.GetRoads(ctx)
+			require.NoError(t, err)
+			require.Len(t, roads, 7)
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "grid.json")
+	executeCommand(t, "generate", path, "--width", "4", "--height", "4")
+
+	world, err := loadMapFiles(ctx, []string{path}, "auto", types.ClassicDirections, false, nil)
+	require.NoError(t, err)
+	city, err := world.GetCity(ctx, "City_4_4")
+	require.NoError(t, err)
+	require.NotNil(t, city)
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the current output")
+
+// goldenDir holds the expected output of the commands run on every map
+const goldenDir = "../test_data/golden"
+
+func Test_Golden(t *testing.T) {
+	entries, err := os.ReadDir("../test_data")
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		mapFile := "../test_data/" + entry.Name()
+		tests := []struct {
+			golden string
+			args   []string
+		}{
+			{
+				golden: entry.Name() + ".run.golden",
+				args:   []string{"-m", mapFile, "-n", "4", "-s", "50", "--seed", "1", "--positions", "--fragmentation"},
+			},
+			{
+				golden: entry.Name() + ".road-fights.golden",
+				args:   []string{"-m", mapFile, "-n", "10", "-s", "50", "--seed", "2", "--road-fights", "--two-way"},
+			},
+			{
+				golden: entry.Name() + ".analyze.json.golden",
+				args:   []string{"analyze", mapFile, "--output", "json"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.golden, func(t *testing.T) {
+				got := executeCommand(t, tt.args...)
+				checkGolden(t, filepath.Join(goldenDir, tt.golden), got)
+			})
+		}
+	}
+}
+
+// executeCommand runs the root command with arguments, every flag starting from its default, and retrieves its output
+func executeCommand(t *testing.T, args ...string) string {
+	t.Helper()
+
+	out, err := executeCommandWithEnv(t, nil, args...)
+	require.NoError(t, err)
+	return out
+}
+
+// executeCommandWithEnv runs the root command with arguments and environment variables, the ALIEN_* variables
+// of the machine running the tests being ignored, and retrieves its output and error
+func executeCommandWithEnv(t *testing.T, env map[string]string, args ...string) (string, error) {
+	t.Helper()
+
+	resetFlags(rootCmd)
+	out := &bytes.Buffer{}
+	rootCmd.SetArgs(args)
+	rootCmd.SetOut(out)
+	rootCmd.SetErr(out)
+	lookupEnv = func(name string) (string, bool) {
+		value, found := env[name]
+		return value, found
+	}
+	t.Cleanup(func() {
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		lookupEnv = os.LookupEnv
+		resetFlags(rootCmd)
+	})
+
+	err := rootCmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+// resetFlags sets the flags of a command and its subcommands back to their defaults
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			defaults := []string{}
+			if values := strings.Trim(f.DefValue, "[]"); values != "" {
+				defaults = strings.Split(values, ",")
+			}
+			_ = slice.Replace(defaults)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+		delete(configSources, f)
+	}
+
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
+
+// checkGolden compares an output to its golden file, rewriting the golden file with -update
+func checkGolden(t *testing.T, path, got string) {
+	t.Helper()
+
+	if *update {
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test ./cmd -run Golden -update to create the golden file")
+	require.Equal(t, string(want), got)
+}
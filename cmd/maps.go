@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"alien-invasion-cc/engine"
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+)
+
+// mapInput Type definition, the map files given to a command, read as a single input or merged into one map
+type mapInput struct {
+	// Input of a single map
+	in io.Reader
+	// Format of a single map, detected from the content when auto
+	format mapfile.Format
+	// Reader merging several maps, nil for a single map
+	reader mapfile.MapReader
+	// Files opened
+	files []io.Closer
+}
+
+// openMaps opens map files, - reading stdin and .gz files being decompressed, their format given by name
+// or detected from their extension; several maps are merged, a city defined in two maps failing the load
+func openMaps(paths []string, formatName string, stdin io.Reader) (_ *mapInput, err error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no map file given")
+	}
+
+	stdinPaths := 0
+	for _, path := range paths {
+		if path == mapfile.StdinPath {
+			stdinPaths++
+		}
+	}
+
+	if stdinPaths > 1 {
+		return nil, fmt.Errorf("the standard input is read once, %s is given %d times", mapfile.StdinPath, stdinPaths)
+	}
+
+	format, err := mapfile.ParseFormat(formatName)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &mapInput{}
+	defer func() {
+		if err != nil {
+			_ = input.Close()
+		}
+	}()
+
+	sources := make([]mapfile.Source, 0, len(paths))
+	for _, path := range paths {
+		in, err := mapfile.Open(path, stdin)
+		if err != nil {
+			return nil, err
+		}
+		input.files = append(input.files, in)
+
+		pathFormat := format
+		if pathFormat == mapfile.FormatAuto {
+			pathFormat = mapfile.FormatFromPath(path)
+		}
+
+		if len(paths) == 1 {
+			input.in, input.format = in, pathFormat
+			return input, nil
+		}
+
+		reader, err := mapfile.NewMapReader(in, pathFormat)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		sources = append(sources, mapfile.Source{Name: path, Reader: reader})
+	}
+
+	input.reader = mapfile.NewMergedReader(sources...)
+	return input, nil
+}
+
+// merged checks if several maps are merged
+func (m *mapInput) merged() bool {
+	return m.reader != nil
+}
+
+// mapReader retrieves the reader of the map
+func (m *mapInput) mapReader() (mapfile.MapReader, error) {
+	if m.reader != nil {
+		return m.reader, nil
+	}
+	return mapfile.NewMapReader(m.in, m.format)
+}
+
+// options retrieves the engine options loading the map
+func (m *mapInput) options() []engine.Option {
+	if m.reader != nil {
+		return []engine.Option{engine.WithMapReader(m.reader)}
+	}
+	return []engine.Option{engine.WithMapFormat(m.format)}
+}
+
+// Close closes the files opened
+func (m *mapInput) Close() error {
+	var err error
+	for _, f := range m.files {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// loadMapFiles loads map files into a world with no alien, merging several maps
+func loadMapFiles(ctx context.Context, paths []string, formatName string, directions *types.DirectionProfile, twoWay bool, stdin io.Reader) (engine.World, error) {
+	input, err := openMaps(paths, formatName, stdin)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = input.Close() }()
+
+	return loadMapInput(ctx, input, directions, twoWay)
+}
+
+// loadMap loads a map into a world with no alien
+func loadMap(ctx context.Context, in io.Reader, format mapfile.Format, directions *types.DirectionProfile, twoWay bool) (engine.World, error) {
+	return loadMapInput(ctx, &mapInput{in: in, format: format}, directions, twoWay)
+}
+
+// loadMapInput loads the maps opened into a world with no alien
+func loadMapInput(ctx context.Context, input *mapInput, directions *types.DirectionProfile, twoWay bool) (engine.World, error) {
+	opts := append(input.options(),
+		engine.WithDirectionProfile(directions),
+		engine.WithAutoReverseRoads(twoWay),
+	)
+
+	e := engine.NewEngine(0, 0, input.in, io.Discard, opts...)
+	err := e.LoadEngine(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.World(), nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+)
+
+func Test_openMaps(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	east := filepath.Join(dir, "east.json")
+	require.NoError(t, os.WriteFile(east, []byte(`{"cities": [{"name": "Kyiv", "roads": [{"direction": "west", "to": "Warsaw"}]}]}`), 0o644))
+
+	compressed := filepath.Join(dir, "world.map.gz")
+	f, err := os.Create(compressed)
+	require.NoError(t, err)
+	zw := gzip.NewWriter(f)
+	_, err = zw.Write([]byte("Foo north=Bar\nBar south=Foo\n"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+
+	countCities := func(paths []string, stdin string) int {
+		world, err := loadMapFiles(ctx, paths, "auto", types.ClassicDirections, false, strings.NewReader(stdin))
+		require.NoError(t, err)
+		cities, err := world.GetCities(ctx)
+		require.NoError(t, err)
+		return len(cities)
+	}
+
+	require.Equal(t, 2, countCities([]string{compressed}, ""))
+	require.Equal(t, 1, countCities([]string{mapfile.StdinPath}, "Lone\n"))
+	require.Equal(t, 11, countCities([]string{"../test_data/test_map", east}, ""))
+	require.Equal(t, 15, countCities([]string{"../test_data/test_map", east, mapfile.StdinPath, compressed}, "Lone\nOther\n"))
+
+	input, err := openMaps([]string{"../test_data/test_map.json"}, "auto", nil)
+	require.NoError(t, err)
+	require.False(t, input.merged())
+	require.Equal(t, mapfile.FormatJSON, input.format)
+	require.NoError(t, input.Close())
+
+	_, err = loadMapFiles(ctx, []string{"../test_data/test_map", "../test_data/test_map.json"}, "auto", types.ClassicDirections, false, nil)
+	require.ErrorIs(t, err, types.ERR_DUPLICATE_CITY)
+
+	_, err = openMaps([]string{mapfile.StdinPath, mapfile.StdinPath}, "auto", nil)
+	require.EqualError(t, err, "the standard input is read once, - is given 2 times")
+
+	_, err = openMaps(nil, "auto", nil)
+	require.EqualError(t, err, "no map file given")
+
+	_, err = openMaps([]string{"../test_data/test_map", filepath.Join(dir, "missing")}, "auto", nil)
+	require.Error(t, err)
+}
+
+func Test_runSimulation_MergedMaps(t *testing.T) {
+	east := filepath.Join(t.TempDir(), "east.map")
+	require.NoError(t, os.WriteFile(east, []byte("Kyiv west=Warsaw\n"), 0o644))
+
+	out := executeCommand(t, "run", "-m", "../test_data/test_map", "-m", east, "-n", "2", "--seed", "1")
+	require.Contains(t, out, "Map File Path:../test_data/test_map, "+east+"\n")
+
+	_, err := executeCommandWithEnv(t, nil, "run", "-m", "../test_data/test_map", "-m", east, "--stream")
+	require.EqualError(t, err, "--stream and --parallel load a single map, 2 maps are given")
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"net"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"alien-invasion-cc/metrics"
+)
+
+// metricsVar is the expvar variable the metrics are published as
+const metricsVar = "alien_invasion"
+
+// serveMetrics serves the metrics of a registry in the background, on /metrics and /debug/vars, until stopped
+func serveMetrics(listener net.Listener, registry *metrics.Registry) func() {
+	srv := &http.Server{
+		Handler: registry.ServeMux(),
+	}
+
+	go func() {
+		err := srv.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			log.Warnf("serving the metrics: %v", err)
+		}
+	}()
+
+	return func() { _ = srv.Close() }
+}
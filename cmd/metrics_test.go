@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"alien-invasion-cc/metrics"
+)
+
+func Test_serveMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	registry.NewCounter("steps_total", "Steps executed.").Add(7)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	stop := serveMetrics(listener, registry)
+
+	response, err := http.Get("http://" + listener.Addr().String() + "/metrics")
+	require.NoError(t, err)
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	require.Contains(t, string(body), "steps_total 7\n")
+
+	// the metrics stop being served once stopped
+	stop()
+	_, err = http.Get("http://" + listener.Addr().String() + "/metrics")
+	require.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"alien-invasion-cc/engine"
+	"alien-invasion-cc/engine/types"
+)
+
+var (
+	pathFastest bool
+	pathHops    int
+)
+
+// pathCmd finds the shortest path between two cities of a map
+var pathCmd = &cobra.Command{
+	Use:   "path <from> <to>",
+	Short: "Find the shortest path between two cities of a map",
+	Long: `Find the shortest path between two cities of a map, following the roads leading out of the cities.
+
+The path follows the fewest roads by default, or takes the fewest steps with --fastest.
+The map given by --file is the initial map of a run, or the world left after an invasion written with --output-map.`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeCities(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		directions, err := types.GetDirectionProfile(directionProfile)
+		if err != nil {
+			return err
+		}
+
+		world, err := loadMapFiles(cmd.Context(), mapFiles, mapFormat, directions, twoWayRoads, cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+
+		report, err := findPath(cmd.Context(), world, args[0], args[1], pathFastest, pathHops)
+		if err != nil {
+			return err
+		}
+
+		return writeReport(cmd.OutOrStdout(), analyzeOutput, report)
+	},
+}
+
+func init() {
+	pathCmd.Flags().BoolVar(&pathFastest, "fastest", false, "take the fewest steps instead of following the fewest roads, roads longer than one step counting their length")
+	pathCmd.Flags().IntVar(&pathHops, "hops", 0, "also list the cities reachable from the starting city following at most this many roads, -1 for every reachable city")
+	analyzeCmd.AddCommand(pathCmd)
+}
+
+// PathReport Type definition, a path between two cities and the neighbourhood of the starting city
+type PathReport struct {
+	// Starting city
+	From string `json:"from"`
+	// Destination city
+	To string `json:"to"`
+	// Cities travelled through, both ends included
+	Cities []string `json:"cities"`
+	// Number of roads followed
+	Roads int `json:"roads"`
+	// Number of steps needed to travel the path
+	Steps uint `json:"steps"`
+	// Cities reachable from the starting city within the requested number of roads, nearest first
+	Neighbourhood []string `json:"neighbourhood,omitempty"`
+}
+
+// findPath finds the path between two cities, and the neighbourhood of the starting city unless hops is 0
+func findPath(ctx context.Context, world engine.World, from, to string, fastest bool, hops int) (*PathReport, error) {
+	find := engine.ShortestPath
+	if fastest {
+		find = engine.FastestPath
+	}
+
+	path, err := find(ctx, world, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PathReport{From: from, To: to, Cities: path.Names(), Roads: path.Roads(), Steps: path.Steps}
+	if hops == 0 {
+		return report, nil
+	}
+
+	cities, err := engine.Neighbourhood(ctx, world, from, hops)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Neighbourhood = (&engine.Path{Cities: cities}).Names()
+	return report, nil
+}
+
+// WriteTable writes the path as aligned text
+func (r *PathReport) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Path\t%s\n", strings.Join(r.Cities, " -> "))
+	fmt.Fprintf(tw, "Roads\t%d\n", r.Roads)
+	fmt.Fprintf(tw, "Steps\t%d\n", r.Steps)
+	if r.Neighbourhood != nil {
+		fmt.Fprintf(tw, "Neighbourhood\t%d\t%s\n", len(r.Neighbourhood), strings.Join(r.Neighbourhood, ", "))
+	}
+
+	return tw.Flush()
+}
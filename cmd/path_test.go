@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_findPath(t *testing.T) {
+	ctx := context.Background()
+
+	world, err := loadMapFiles(ctx, []string{"../test_data/test_map"}, "auto", types.ClassicDirections, false, nil)
+	require.NoError(t, err)
+
+	report, err := findPath(ctx, world, "London", "Rome", false, 0)
+	require.NoError(t, err)
+	require.Equal(t, &PathReport{
+		From:   "London",
+		To:     "Rome",
+		Cities: []string{"London", "Paris", "Barcelona", "Rome"},
+		Roads:  3,
+		Steps:  3,
+	}, report)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, report.WriteTable(out))
+	require.Equal(t, "Path   London -> Paris -> Barcelona -> Rome\nRoads  3\nSteps  3\n", out.String())
+
+	report, err = findPath(ctx, world, "Paris", "Warsaw", true, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Paris", "Berlin", "Warsaw"}, report.Cities)
+	require.ElementsMatch(t, []string{"Brussels", "London", "Berlin", "Barcelona"}, report.Neighbourhood)
+
+	_, err = findPath(ctx, world, "London", "Athens", false, 0)
+	require.ErrorIs(t, err, types.ERR_NO_PATH)
+	require.EqualError(t, err, "no path leads between the cities: from London to Athens")
+}
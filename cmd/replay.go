@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"alien-invasion-cc/engine"
+)
+
+var (
+	replayDelay time.Duration
+	replayTypes []string
+)
+
+// eventTypes lists the types of events a replay may tell
+var eventTypes = []string{
+	string(engine.EventLanded), string(engine.EventMoved), string(engine.EventDeparted), string(engine.EventFought),
+	string(engine.EventDestroyed), string(engine.EventStranded), string(engine.EventRoadFight), string(engine.EventFinished),
+}
+
+// replayCmd tells again an invasion recorded with --events-file
+var replayCmd = &cobra.Command{
+	Use:   "replay <events-file>",
+	Short: "Replay an invasion recorded with --events-file, step by step",
+	Long: `Replay an invasion recorded with --events-file, telling its events step by step.
+
+--delay paces the replay, waiting between two steps, and --types only tells some kinds of events:
+landed, moved, departed, fought, destroyed, stranded, road_fight and finished.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFileExtensions("jsonl", "json"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer func() { _ = in.Close() }()
+
+		return replayEvents(cmd.Context(), in, cmd.OutOrStdout(), replayDelay, replayTypes)
+	},
+}
+
+func init() {
+	replayCmd.Flags().DurationVar(&replayDelay, "delay", 0, "time waited between two steps")
+	replayCmd.Flags().StringSliceVar(&replayTypes, "types", nil, "types of the events told, every type by default")
+	_ = replayCmd.RegisterFlagCompletionFunc("types", completeValues(eventTypes...))
+	rootCmd.AddCommand(replayCmd)
+}
+
+// recordEvents writes the events handed to the listener retrieved into a file, as JSON lines;
+// the function retrieved closes the file, reporting the first write error
+func recordEvents(path string) (func(engine.Event), func() error, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w := bufio.NewWriter(f)
+	encoder := json.NewEncoder(w)
+
+	var writeErr error
+	listener := func(event engine.Event) {
+		if writeErr == nil {
+			writeErr = encoder.Encode(event)
+		}
+	}
+
+	return listener, func() error {
+		if writeErr == nil {
+			writeErr = w.Flush()
+		}
+		closeErr := f.Close()
+		if writeErr != nil {
+			return writeErr
+		}
+		return closeErr
+	}, nil
+}
+
+// replayEvents tells the events read as JSON lines, waiting for the delay between two steps
+func replayEvents(ctx context.Context, in io.Reader, out io.Writer, delay time.Duration, types []string) error {
+	told := map[engine.EventType]bool{}
+	for _, t := range types {
+		if !contains(eventTypes, t) {
+			return fmt.Errorf("unknown event type %q, expected one of %s", t, strings.Join(eventTypes, ", "))
+		}
+		told[engine.EventType(t)] = true
+	}
+
+	decoder := json.NewDecoder(in)
+	step := uint(0)
+	for line := 1; ; line++ {
+		event := engine.Event{}
+		err := decoder.Decode(&event)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("event %d: %w", line, err)
+		}
+
+		if len(told) > 0 && !told[event.Type] {
+			continue
+		}
+
+		if event.Step != step && delay > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+		step = event.Step
+
+		_, err = fmt.Fprintf(out, "[step %d] %s\n", event.Step, describeEvent(event))
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// describeEvent tells an event in a sentence
+func describeEvent(event engine.Event) string {
+	aliens := alienNames(event.Aliens)
+
+	switch event.Type {
+	case engine.EventLanded:
+		return fmt.Sprintf("%s landed in %s", aliens, event.City)
+	case engine.EventMoved:
+		return fmt.Sprintf("%s moved from %s to %s", aliens, event.From, event.City)
+	case engine.EventDeparted:
+		return fmt.Sprintf("%s left %s for %s on the road %s", aliens, event.From, event.City, event.Road)
+	case engine.EventFought:
+		return fmt.Sprintf("%s fought in %s", aliens, event.City)
+	case engine.EventDestroyed:
+		return fmt.Sprintf("%s has been destroyed", event.City)
+	case engine.EventStranded:
+		return fmt.Sprintf("%s is stranded on the road %s to destroyed %s", aliens, event.Road, event.City)
+	case engine.EventRoadFight:
+		return fmt.Sprintf("%s fought on the road %s", aliens, event.Road)
+	case engine.EventFinished:
+		return fmt.Sprintf("the invasion is over: %s", event.Reason)
+	}
+
+	return fmt.Sprintf("%s: %s %s", event.Type, aliens, event.City)
+}
+
+// alienNames names aliens, e.g. Alien #1, Alien #2 and Alien #3
+func alienNames(ids []int) string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = fmt.Sprintf("Alien #%d", id)
+	}
+
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// contains checks if a value is listed
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
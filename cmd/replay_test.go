@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"alien-invasion-cc/engine"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_replayEvents(t *testing.T) {
+	ctx := context.Background()
+
+	events := `{"step":0,"type":"landed","aliens":[1],"city":"Foo"}
+{"step":0,"type":"landed","aliens":[2],"city":"Bar"}
+{"step":1,"type":"moved","aliens":[1],"city":"Bar","from":"Foo"}
+{"step":1,"type":"fought","aliens":[1,2],"city":"Bar"}
+{"step":1,"type":"destroyed","city":"Bar"}
+{"step":1,"type":"finished","reason":"all_trapped"}
+`
+
+	out := &bytes.Buffer{}
+	require.NoError(t, replayEvents(ctx, strings.NewReader(events), out, 0, nil))
+	require.Equal(t, `[step 0] Alien #1 landed in Foo
+[step 0] Alien #2 landed in Bar
+[step 1] Alien #1 moved from Foo to Bar
+[step 1] Alien #1 and Alien #2 fought in Bar
+[step 1] Bar has been destroyed
+[step 1] the invasion is over: every alien is trapped
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, replayEvents(ctx, strings.NewReader(events), out, 0, []string{"destroyed"}))
+	require.Equal(t, "[step 1] Bar has been destroyed\n", out.String())
+
+	err := replayEvents(ctx, strings.NewReader(events), out, 0, []string{"exploded"})
+	require.EqualError(t, err, `unknown event type "exploded", expected one of landed, moved, departed, fought, destroyed, stranded, road_fight, finished`)
+
+	err = replayEvents(ctx, strings.NewReader(events+"{\n"), out, 0, nil)
+	require.EqualError(t, err, "event 7: unexpected EOF")
+
+	// the replay waits between two steps, until cancelled
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = replayEvents(cancelled, strings.NewReader(events), out, time.Hour, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_describeEvent(t *testing.T) {
+	require.Equal(t, "Alien #3 left Foo for Bar on the road Foo -> Bar", describeEvent(engine.Event{Type: engine.EventDeparted, Aliens: []int{3}, City: "Bar", From: "Foo", Road: "Foo -> Bar"}))
+	require.Equal(t, "Alien #1, Alien #2 and Alien #3", alienNames([]int{1, 2, 3}))
+	require.Equal(t, "", alienNames(nil))
+}
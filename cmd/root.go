@@ -8,89 +8,164 @@ import (
 	"context"
 	"io"
 	"os"
-	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
 	"github.com/spf13/cobra"
 	"alien-invasion-cc/engine"
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
 )
 
 var (
 	numAliens uint
 	maxMoves 	uint
-	mapFile string
+	mapFiles []string
+	twoWayRoads bool
+	directionProfile string
+	roadFights bool
+	mapFormat string
+	outputMapFile string
+	sortedOutputMap bool
+	destroyedInOutputMap bool
+	streamMap bool
+	maxLineSize int
+	showProgress bool
+	parallelWorkers int
+	seed int64
+	metricsAddr string
+	traceFile string
+	timeout time.Duration
+	reportPositions bool
+	reportFragmentation bool
+	scenarioFile string
+	eventsFile string
 )
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "alien-invasion-cc",
-	Short: "A brief description of your application",
-	Long: `A longer description that spans multiple lines and likely contains
-examples and usage of using your application. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
-	// Uncomment the following line if your bare application
-	// has an action associated with it:
-	 RunE: func(cmd *cobra.Command, args []string) error { 
-		in, err := os.Open(mapFile)
-		defer func() { _ = in.Close() }()
-		if err != nil {
-			return err
-		}
-
-		c := &config{
-			numAliens: 	numAliens,
-			maxMoves: 		maxMoves,
-			in: 			in,
-			out: 			cmd.OutOrStdout(),
-		}
-		fmt.Printf("Map File Path:%v\n", mapFile)
-		fmt.Printf("Number Of Aliens:%v\n", numAliens)
-		fmt.Printf("Max Moves:%v\n\n", maxMoves)
-
-
-
-		return runEngine(cmd.Context(), c)
-	 },
+	Short: "Simulate an alien invasion of a map of cities",
+	Long: `Simulate an alien invasion of a map of cities.
+
+Aliens land in random cities and wander along the roads; two aliens meeting in a city fight,
+destroying the city, themselves and the roads leading to it. The invasion ends once every alien
+is dead or trapped, or after the maximum number of steps.
+
+Without a subcommand, the invasion is run as with the run command.`,
+	Args: cobra.NoArgs,
+	RunE: runSimulation,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// An interrupt or a termination signal cancels the command, a second one kills it.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
 	if err != nil {
 		os.Exit(1)
 	}
 }
 
 func init() {
-	// Here you will define your flags and configuration settings.
-	// Cobra supports persistent flags, which, if defined here,
-	// will be global for your application.
-
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.alien-invasion-cc.yaml)")
-
-	// Cobra also supports local flags, which will only run
-	// when this action is called directly.
-	rootCmd.Flags().UintVarP(&numAliens, "aliens", "n", 5, "number of aliens to be spawned")
-	rootCmd.Flags().UintVarP(&maxMoves, "steps", "s", 10000, "number of maximum moves")
-	rootCmd.Flags().StringVarP(&mapFile, "file", "m", "test_data/test_map", "map file path")
+	addRunFlags(rootCmd)
 }
 
 type config struct {
 	numAliens, maxMoves 	uint
-	in						io.ReadCloser
+	in						io.Reader
+	mapReader				mapfile.MapReader
 	out 					io.Writer
+	twoWayRoads				bool
+	directions				*types.DirectionProfile
+	roadFights				bool
+	mapFormat				mapfile.Format
+	mapOut					io.Writer
+	mapWriterOptions		[]mapfile.WriterOption
+	streamMap				bool
+	maxLineSize				int
+	progress				func(engine.LoadProgress)
+	workers					int
+	seed					*int64
+	metrics					*engine.Metrics
+	timeout					time.Duration
+	reportPositions			bool
+	reportFragmentation		bool
+	scenarioOptions			[]engine.Option
+	eventListener			func(engine.Event)
 }
 
 func runEngine(ctx context.Context, c *config) error {
 
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	// the options of a scenario come first, the values of the flags overriding them; applyScenario set the flags
+	// the command line leaves unset to the values of the scenario
+	opts := append([]engine.Option{}, c.scenarioOptions...)
+	opts = append(opts,
+		engine.WithAutoReverseRoads(c.twoWayRoads),
+		engine.WithDirectionProfile(c.directions),
+		engine.WithRoadFights(c.roadFights),
+		engine.WithMapFormat(c.mapFormat),
+		engine.WithPositionsReport(c.reportPositions),
+		engine.WithFragmentationReport(c.reportFragmentation),
+	)
+
+	if c.seed != nil {
+		opts = append(opts, engine.WithSeed(*c.seed))
+	}
+
+	if c.metrics != nil {
+		opts = append(opts, engine.WithMetrics(c.metrics))
+	}
+
+	if c.mapReader != nil {
+		opts = append(opts, engine.WithMapReader(c.mapReader))
+	}
+
+	if c.eventListener != nil {
+		opts = append(opts, engine.WithEventListener(c.eventListener))
+	}
+
+	loaderOptions := []engine.LoaderOption{engine.WithMaxLineSize(c.maxLineSize)}
+	if c.progress != nil {
+		loaderOptions = append(loaderOptions, engine.WithProgress(c.progress, engine.DefaultProgressInterval))
+	}
+
+	if c.streamMap {
+		opts = append(opts, engine.WithStreamingLoader(loaderOptions...))
+	} else if c.workers > 0 {
+		loaderOptions = append(loaderOptions, engine.WithWorkers(c.workers))
+		opts = append(opts, engine.WithParallelLoader(loaderOptions...))
+	}
+
 	gameEngine := engine.NewEngine(
 		c.numAliens,
 		c.maxMoves,
 		c.in,
 		c.out,
+		opts...,
 	)
 
-	return gameEngine.Run(ctx)
+	err := gameEngine.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	if c.mapOut == nil {
+		return nil
+	}
+
+	return mapfile.NewMapWriter(c.mapOut, c.mapWriterOptions...).WriteWorld(ctx, gameEngine.World())
 }
@@ -3,10 +3,14 @@ package cmd
 import (
 	"context"
 	"io"
+	"math"
+	"os"
 	"strings"
 	"bytes"
 	"testing"
+	"time"
 
+	"alien-invasion-cc/engine/mapfile"
 	"alien-invasion-cc/engine/types"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
@@ -85,4 +89,75 @@ City1 test=City2
 			require.Equal(t, tt.wantError, err)
 		})
 	}
-}
\ No newline at end of file
+}
+func Test_runEngine_OutputMap(t *testing.T) {
+	log.SetLevel(log.WarnLevel)
+	ctx := context.Background()
+
+	input := `City1 north=City2
+City2 east=City3 south=City1
+City3 west=City2
+`
+
+	mapOut := &bytes.Buffer{}
+	c := &config{
+		numAliens:        0,
+		maxMoves:         10,
+		in:               io.NopCloser(strings.NewReader(input)),
+		out:              &bytes.Buffer{},
+		mapOut:           mapOut,
+		mapWriterOptions: []mapfile.WriterOption{mapfile.WithSortedCities(true)},
+	}
+	err := runEngine(ctx, c)
+	require.NoError(t, err)
+	require.Equal(t, input, mapOut.String())
+}
+
+func Test_runEngine_Seed(t *testing.T) {
+	log.SetLevel(log.WarnLevel)
+	ctx := context.Background()
+
+	input, err := os.ReadFile("../test_data/test_map2")
+	require.NoError(t, err)
+
+	runSeeded := func(seed int64) string {
+		out := &bytes.Buffer{}
+		c := &config{
+			numAliens: 30,
+			maxMoves:  100,
+			in:        io.NopCloser(bytes.NewReader(input)),
+			out:       out,
+			seed:      &seed,
+		}
+		require.NoError(t, runEngine(ctx, c))
+		return out.String()
+	}
+
+	require.Equal(t, runSeeded(3), runSeeded(3))
+	require.NotEqual(t, runSeeded(3), runSeeded(4))
+}
+
+func Test_runEngine_Timeout(t *testing.T) {
+	log.SetLevel(log.ErrorLevel)
+	defer log.SetLevel(log.WarnLevel)
+
+	input, err := os.ReadFile("../test_data/test_map_circuit")
+	require.NoError(t, err)
+
+	// two aliens chase each other around the one-way circuit, only the timeout ends their run
+	seed := int64(1)
+	out := &bytes.Buffer{}
+	c := &config{
+		numAliens: 2,
+		maxMoves:  math.MaxUint32,
+		in:        io.NopCloser(bytes.NewReader(input)),
+		out:       out,
+		seed:      &seed,
+		timeout:   50 * time.Millisecond,
+	}
+
+	err = runEngine(context.Background(), c)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Contains(t, out.String(), "Simulation Cancelled")
+	require.Contains(t, out.String(), "Remain Cities: 4")
+}
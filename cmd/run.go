@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"alien-invasion-cc/engine"
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+	"alien-invasion-cc/metrics"
+	"alien-invasion-cc/scenario"
+)
+
+// outputFileMode is the mode of the output map, the temporary files it is written to being private
+const outputFileMode = 0o644
+
+// runCmd runs an invasion, the bare root command being an alias of it
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run an alien invasion of a map",
+	Long: `Run an alien invasion of a map, reporting the destroyed cities and the world left.
+
+The flags are the flags of the bare root command, which runs the invasion the same way.`,
+	Args: cobra.NoArgs,
+	RunE: runSimulation,
+}
+
+func init() {
+	addRunFlags(runCmd)
+	rootCmd.AddCommand(runCmd)
+}
+
+// runSimulation runs the invasion set up by the flags, for run and the bare root command
+func runSimulation(cmd *cobra.Command, args []string) (err error) {
+	var (
+		sc        *scenario.Scenario
+		inlineMap string
+	)
+	if scenarioFile != "" {
+		sc, err = scenario.Load(scenarioFile)
+		if err != nil {
+			return err
+		}
+
+		// a map file given on the command line replaces the map written in the scenario
+		if !givenOnCommandLine(cmd.Flags().Lookup("file")) {
+			inlineMap = sc.Map.Inline
+		}
+
+		err = applyScenario(cmd.Flags(), sc)
+		if err != nil {
+			return err
+		}
+
+		err = sc.CheckPlacement(numAliens)
+		if err != nil {
+			return err
+		}
+	}
+
+	directions, err := types.GetDirectionProfile(directionProfile)
+	if err != nil {
+		return err
+	}
+
+	if streamMap && parallelWorkers > 0 {
+		return fmt.Errorf("--stream and --parallel can't be combined")
+	}
+
+	input := &mapInput{in: strings.NewReader(inlineMap)}
+	if inlineMap != "" {
+		input.format, err = mapfile.ParseFormat(mapFormat)
+	} else {
+		input, err = openMaps(mapFiles, mapFormat, cmd.InOrStdin())
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = input.Close() }()
+
+	if input.merged() && (streamMap || parallelWorkers > 0) {
+		return fmt.Errorf("--stream and --parallel load a single map, %d maps are given", len(mapFiles))
+	}
+
+	c := &config{
+		numAliens:           numAliens,
+		maxMoves:            maxMoves,
+		in:                  input.in,
+		mapReader:           input.reader,
+		out:                 cmd.OutOrStdout(),
+		twoWayRoads:         twoWayRoads,
+		directions:          directions,
+		roadFights:          roadFights,
+		mapFormat:           input.format,
+		streamMap:           streamMap,
+		maxLineSize:         maxLineSize,
+		workers:             parallelWorkers,
+		timeout:             timeout,
+		reportPositions:     reportPositions,
+		reportFragmentation: reportFragmentation,
+	}
+
+	if sc != nil {
+		c.scenarioOptions, err = sc.EngineOptions()
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("seed") {
+		c.seed = &seed
+	}
+
+	if metricsAddr != "" {
+		registry := metrics.NewRegistry()
+		err = registry.Publish(metricsVar)
+		if err != nil {
+			return err
+		}
+
+		listener, err := net.Listen("tcp", metricsAddr)
+		if err != nil {
+			return err
+		}
+		defer serveMetrics(listener, registry)()
+		log.Warnf("serving the metrics on %s", listener.Addr())
+
+		c.metrics = engine.NewMetrics(registry)
+	}
+
+	ctx := cmd.Context()
+	if traceFile != "" {
+		var closeTrace func() error
+		ctx, closeTrace, err = traceToFile(ctx, traceFile)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := closeTrace(); err == nil {
+				err = closeErr
+			}
+		}()
+	}
+
+	if eventsFile != "" {
+		var closeEvents func() error
+		c.eventListener, closeEvents, err = recordEvents(eventsFile)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := closeEvents(); err == nil {
+				err = closeErr
+			}
+		}()
+	}
+
+	if showProgress {
+		c.progress = func(p engine.LoadProgress) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Loading map: pass %d/2, %d lines, %d bytes, %d cities, %d roads\n", p.Pass, p.Lines, p.Bytes, p.Cities, p.Roads)
+		}
+	}
+
+	var mapOut *outputFile
+	if outputMapFile != "" {
+		mapOut, err = createOutputFile(outputMapFile)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = mapOut.Close() }()
+
+		c.mapOut = mapOut
+		c.mapWriterOptions = []mapfile.WriterOption{
+			mapfile.WithSortedCities(sortedOutputMap),
+			mapfile.WithDestroyedCities(destroyedInOutputMap),
+		}
+	}
+
+	if sc != nil && sc.Name != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Scenario:%v\n", sc.Name)
+	}
+	if inlineMap != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Map File Path:%v\n", scenarioFile)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "Map File Path:%v\n", strings.Join(mapFiles, ", "))
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Number Of Aliens:%v\n", numAliens)
+	fmt.Fprintf(cmd.OutOrStdout(), "Max Moves:%v\n\n", maxMoves)
+
+	err = runEngine(ctx, c)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// the run was cut short, the command was not misused
+		cmd.SilenceUsage = true
+	}
+	if err != nil {
+		return err
+	}
+
+	if mapOut != nil {
+		return mapOut.Commit()
+	}
+	return nil
+}
+
+// outputFile Type definition, a file written under a temporary name next to its path, replacing the file
+// at its path once committed so that a failed run leaves a previous file untouched
+type outputFile struct {
+	*os.File
+	// Path the file is committed to
+	path string
+	// Flag whether the file was committed
+	committed bool
+}
+
+// createOutputFile creates a temporary file in the directory of path
+func createOutputFile(path string) (*outputFile, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &outputFile{File: f, path: path}, nil
+}
+
+// Commit closes the temporary file and renames it to the path
+func (f *outputFile) Commit() error {
+	err := f.File.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(f.Name(), outputFileMode)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(f.Name(), f.path)
+	if err != nil {
+		return err
+	}
+
+	f.committed = true
+	return nil
+}
+
+// Close closes and removes the temporary file if it was not committed
+func (f *outputFile) Close() error {
+	if f.committed {
+		return nil
+	}
+
+	_ = f.File.Close()
+	return os.Remove(f.Name())
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"alien-invasion-cc/scenario"
+)
+
+func Test_runSimulation(t *testing.T) {
+	args := []string{"-m", "../test_data/test_map", "-n", "4", "-s", "50", "--seed", "1", "--positions"}
+
+	// the bare root command is an alias of run
+	want := executeCommand(t, args...)
+	require.Contains(t, want, "Map File Path:../test_data/test_map\n")
+	require.Equal(t, want, executeCommand(t, append([]string{"run"}, args...)...))
+
+	events := filepath.Join(t.TempDir(), "events.jsonl")
+	require.Equal(t, want, executeCommand(t, append([]string{"run", "--events-file", events}, args...)...))
+
+	out := executeCommand(t, "replay", events, "--types", "landed,finished")
+	require.Contains(t, out, "[step 0] Alien #1 landed in ")
+	require.Regexp(t, `\[step \d+\] the invasion is over: .+\n$`, out)
+	require.NotContains(t, out, " moved from ")
+}
+
+func Test_runSimulation_Scenario(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seedless.yaml")
+	abs, err := filepath.Abs("../test_data/test_map")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("version: 1\nmap:\n  file: "+abs+"\naliens: 4\nsteps: 50\nreports:\n  positions: true\n"), 0o644))
+
+	s, err := scenario.Load(path)
+	require.NoError(t, err)
+	simulation := &bytes.Buffer{}
+	_, err = s.Run(context.Background(), simulation)
+	require.NoError(t, err)
+
+	// a scenario setting no seed is run with the same default seed by run --scenario and by test
+	out := executeCommand(t, "run", "--scenario", path)
+	require.True(t, strings.HasSuffix(out, simulation.String()), out)
+	require.Equal(t, out, executeCommand(t, "run", "--scenario", path))
+}
+
+func Test_createOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "after.map")
+	require.NoError(t, os.WriteFile(path, []byte("Previous\n"), 0o644))
+
+	// a run failing leaves the previous file untouched
+	f, err := createOutputFile(path)
+	require.NoError(t, err)
+	_, err = f.WriteString("Partial")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "Previous\n", string(content))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	f, err = createOutputFile(path)
+	require.NoError(t, err)
+	_, err = f.WriteString("Foo\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Commit())
+	require.NoError(t, f.Close())
+
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "Foo\n", string(content))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(outputFileMode), info.Mode().Perm())
+
+	// the output map of a run is written once it succeeded
+	executeCommand(t, "run", "-m", "../test_data/test_map", "-n", "2", "--seed", "1", "-o", path)
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(content), "Paris")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/pflag"
+
+	"alien-invasion-cc/scenario"
+)
+
+// applyScenario sets the flags to the values of a scenario, the flags given on the command line taking precedence,
+// the scenario taking precedence over the environment and the configuration file
+func applyScenario(flags *pflag.FlagSet, s *scenario.Scenario) error {
+	values := map[string]string{}
+
+	if path := s.MapPath(); path != "" {
+		values["file"] = path
+	}
+
+	if s.Map.Format != "" {
+		values["format"] = s.Map.Format
+	}
+
+	if s.Map.Directions != "" {
+		values["directions"] = s.Map.Directions
+	}
+
+	if s.Map.TwoWay != nil {
+		values["two-way"] = strconv.FormatBool(*s.Map.TwoWay)
+	}
+
+	if s.Aliens != nil {
+		values["aliens"] = strconv.FormatUint(uint64(*s.Aliens), 10)
+	}
+
+	if s.Seed != nil {
+		values["seed"] = strconv.FormatInt(*s.Seed, 10)
+	}
+
+	if s.Steps != nil {
+		values["steps"] = strconv.FormatUint(uint64(*s.Steps), 10)
+	}
+
+	if s.RoadFights != nil {
+		values["road-fights"] = strconv.FormatBool(*s.RoadFights)
+	}
+
+	if s.Timeout != nil {
+		values["timeout"] = s.Timeout.String()
+	}
+
+	if s.Reports.Positions != nil {
+		values["positions"] = strconv.FormatBool(*s.Reports.Positions)
+	}
+
+	if s.Reports.Fragmentation != nil {
+		values["fragmentation"] = strconv.FormatBool(*s.Reports.Fragmentation)
+	}
+
+	for name, value := range values {
+		if givenOnCommandLine(flags.Lookup(name)) {
+			continue
+		}
+
+		err := flags.Set(name, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
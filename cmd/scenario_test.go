@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	configfile "alien-invasion-cc/config"
+	"alien-invasion-cc/scenario"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applyScenario(t *testing.T) {
+	var (
+		file                  string
+		aliens, steps         uint
+		seed                  int64
+		roadFights, positions bool
+		timeout               time.Duration
+	)
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringVarP(&file, "file", "m", "test_data/test_map", "")
+	flags.UintVarP(&aliens, "aliens", "n", 5, "")
+	flags.UintVarP(&steps, "steps", "s", 10000, "")
+	flags.Int64Var(&seed, "seed", 0, "")
+	flags.BoolVar(&roadFights, "road-fights", false, "")
+	flags.BoolVar(&positions, "positions", false, "")
+	flags.DurationVar(&timeout, "timeout", 0, "")
+
+	// the flags given on the command line take precedence over the scenario
+	require.NoError(t, flags.Parse([]string{"--seed", "7", "-n", "2"}))
+
+	// the scenario takes precedence over the environment and the configuration file
+	t.Cleanup(func() { configSources = map[*pflag.Flag]string{} })
+	require.NoError(t, setFlag(flags.Lookup("steps"), configfile.Scalar("20"), "env ALIEN_STEPS"))
+
+	s, err := scenario.Decode(strings.NewReader("version: 1\nmap:\n  file: world.map\naliens: 300\nseed: 3\nsteps: 50\nroad_fights: true\ntimeout: 2s\n"), "scenarios")
+	require.NoError(t, err)
+	require.NoError(t, applyScenario(flags, s))
+
+	require.Equal(t, "scenarios/world.map", file)
+	require.Equal(t, uint(2), aliens)
+	require.Equal(t, uint(50), steps)
+	require.Equal(t, int64(7), seed)
+	require.True(t, roadFights)
+	require.Equal(t, 2*time.Second, timeout)
+	require.True(t, flags.Changed("steps"))
+
+	// values the scenario leaves unset keep their defaults
+	require.False(t, positions)
+	require.False(t, flags.Changed("positions"))
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"alien-invasion-cc/metrics"
+	"alien-invasion-cc/server"
+)
+
+var (
+	serveAddr      string
+	grpcAddr       string
+	maxSimulations int
+	maxMaps        int
+	maxRunning     int
+	maxMapSize     int64
+	maxEvents      int
+	keepAlive      time.Duration
+	allowedOrigins []string
+)
+
+// shutdownTimeout bounds the time the server waits for running requests when stopping
+const shutdownTimeout = 10 * time.Second
+
+// serveCmd exposes the simulator over a REST API
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a REST API to upload maps and drive simulations",
+	Long: `Serve a REST API to upload maps and drive simulations.
+
+  POST   /maps                        upload a map, ?name=&format=&directions=
+  GET    /maps                        list the maps
+  GET    /maps/{id}                   describe a map
+  DELETE /maps/{id}                   delete a map
+  POST   /simulations                 create a simulation: {"map_id", "aliens", "max_moves", "seed", "road_fights", "two_way"}
+  GET    /simulations                 list the simulations
+  GET    /simulations/{id}            fetch the state of a simulation
+  DELETE /simulations/{id}            delete a simulation, stopping its steps
+  POST   /simulations/{id}/step       run steps, ?count= (1 by default)
+  POST   /simulations/{id}/run        run the simulation to its end, ?timeout= bounds the run
+  GET    /simulations/{id}/events     list events, ?offset=&limit=
+  GET    /simulations/{id}/results    download the outcome of a finished simulation
+  GET    /simulations/{id}/stream     stream events as Server-Sent Events, from Last-Event-ID or ?offset=
+  GET    /simulations/{id}/ws         stream events over a WebSocket, from ?offset=
+  GET    /metrics                     metrics of the simulations in the Prometheus text format
+  GET    /debug/vars                  the same metrics through expvar
+
+With --grpc-addr, the Simulator gRPC service of server/simulatorpb/simulator.proto is served too,
+sharing the maps and simulations of the REST API.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listener, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			return err
+		}
+
+		registry := metrics.NewRegistry()
+		err = registry.Publish(metricsVar)
+		if err != nil {
+			_ = listener.Close()
+			return err
+		}
+
+		handler := server.NewServer(
+			server.WithMetrics(registry),
+			server.WithMaxSimulations(maxSimulations),
+			server.WithMaxMaps(maxMaps),
+			server.WithMaxRunning(maxRunning),
+			server.WithMaxMapSize(maxMapSize),
+			server.WithMaxEvents(maxEvents),
+			server.WithKeepAlive(keepAlive),
+			server.WithAllowedOrigins(allowedOrigins...),
+		)
+
+		grpcDone := make(chan error, 1)
+		if grpcAddr == "" {
+			grpcDone <- nil
+		} else {
+			grpcListener, err := net.Listen("tcp", grpcAddr)
+			if err != nil {
+				_ = listener.Close()
+				return err
+			}
+
+			log.Warnf("serving gRPC on %s", grpcListener.Addr())
+			go func() {
+				grpcDone <- serveGRPC(cmd.Context(), grpcListener, handler)
+			}()
+		}
+
+		log.Warnf("serving on %s", listener.Addr())
+		err = serve(cmd.Context(), listener, handler)
+		grpcErr := <-grpcDone
+		if err == nil {
+			err = grpcErr
+		}
+		return err
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "address to listen on")
+	serveCmd.Flags().StringVar(&grpcAddr, "grpc-addr", "", "address to serve the gRPC API on, none by default")
+	serveCmd.Flags().IntVar(&maxSimulations, "max-simulations", server.DefaultMaxSimulations, "number of simulations held at once, 0 for no limit")
+	serveCmd.Flags().IntVar(&maxMaps, "max-maps", server.DefaultMaxMaps, "number of maps held at once, 0 for no limit")
+	serveCmd.Flags().IntVar(&maxRunning, "max-running", 0, "number of simulations running steps at once, 0 for no limit")
+	serveCmd.Flags().Int64Var(&maxMapSize, "max-map-size", server.DefaultMaxMapSize, "size in bytes of the largest map accepted")
+	serveCmd.Flags().IntVar(&maxEvents, "max-events", server.DefaultMaxEvents, "number of events kept per simulation, 0 keeps them all")
+	serveCmd.Flags().DurationVar(&keepAlive, "keep-alive", server.DefaultKeepAlive, "interval between two keep-alive messages of an idle event stream")
+	serveCmd.Flags().StringSliceVar(&allowedOrigins, "allowed-origins", nil, "origins WebSocket clients may connect from besides the server's own, * allows any")
+	rootCmd.AddCommand(serveCmd)
+}
+
+// serve serves a handler until the context is done, then waits for the running requests, ending the event streams
+func serve(ctx context.Context, listener net.Listener, handler *server.Server) error {
+	srv := &http.Server{
+		Handler: handler,
+	}
+	srv.RegisterOnShutdown(handler.CloseStreams)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := srv.Shutdown(shutdownCtx)
+	if err != nil {
+		return err
+	}
+
+	err = <-errs
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// serveGRPC serves the gRPC API of a server until the context is done, then waits for the running calls, ending the event streams
+func serveGRPC(ctx context.Context, listener net.Listener, handler *server.Server) error {
+	srv := handler.NewGRPCServer()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	handler.CloseStreams()
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		srv.Stop()
+	}
+
+	return <-errs
+}
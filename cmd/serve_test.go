@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"alien-invasion-cc/server"
+	"alien-invasion-cc/server/simulatorpb"
+)
+
+func Test_serve(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(ctx, listener, server.NewServer())
+	}()
+
+	url := "http://" + listener.Addr().String()
+	response, err := http.Post(url+"/maps", "text/plain", strings.NewReader("Foo north=Bar\nBar south=Foo\n"))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	m := &server.Map{}
+	require.NoError(t, json.NewDecoder(response.Body).Decode(m))
+	require.NoError(t, response.Body.Close())
+	require.Equal(t, 2, m.Cities)
+
+	response, err = http.Post(url+"/simulations", "application/json", strings.NewReader(`{"map_id":"`+m.ID+`","aliens":1}`))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	state := &server.SimulationState{}
+	require.NoError(t, json.NewDecoder(response.Body).Decode(state))
+	require.NoError(t, response.Body.Close())
+
+	// an event stream left open doesn't hold the shutdown back
+	stream, err := http.Get(url + "/simulations/" + state.ID + "/stream?offset=1")
+	require.NoError(t, err)
+	defer stream.Body.Close()
+	require.Equal(t, http.StatusOK, stream.StatusCode)
+
+	// the server stops with its context
+	cancel()
+	require.NoError(t, <-done)
+	_, err = io.Copy(io.Discard, stream.Body)
+	require.NoError(t, err)
+}
+
+func Test_serveGRPC(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serveGRPC(ctx, listener, server.NewServer())
+	}()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := simulatorpb.NewSimulatorClient(conn)
+	state, err := client.LoadEngine(context.Background(), &simulatorpb.LoadEngineRequest{
+		Map:    &simulatorpb.LoadEngineRequest_Content{Content: []byte("Foo north=Bar\nBar south=Foo\n")},
+		Aliens: 1,
+	})
+	require.NoError(t, err)
+
+	// a Watch stream left open doesn't hold the shutdown back
+	watch, err := client.Watch(context.Background(), &simulatorpb.WatchRequest{SimulationId: state.Id})
+	require.NoError(t, err)
+	landed, err := watch.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "landed", landed.Event.Type)
+
+	cancel()
+	require.NoError(t, <-done)
+}
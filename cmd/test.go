@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"alien-invasion-cc/scenario"
+)
+
+var testVerbose bool
+
+// testCmd runs scenario files and checks their expectations
+var testCmd = &cobra.Command{
+	Use:   "test <directory or scenario>...",
+	Short: "Run scenario files and check the outcome they expect, as a regression suite",
+	Long: `Run scenario files and check the outcome they expect, as a regression suite.
+
+Every .yaml and .yml file of a directory is run, in name order.
+Scenarios setting no seed run with the seed 1, their outcome always being the same.
+Every expectation not met is reported with the value expected and the value reached.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		simulationOut := io.Discard
+		if testVerbose {
+			simulationOut = cmd.OutOrStdout()
+		}
+
+		failed, total, err := testScenarios(cmd.Context(), args, cmd.OutOrStdout(), simulationOut)
+		if err != nil {
+			return err
+		}
+
+		if failed > 0 {
+			// the failures were reported, the command was not misused
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d of %d scenarios failed", failed, total)
+		}
+		return nil
+	},
+}
+
+func init() {
+	testCmd.Flags().BoolVarP(&testVerbose, "verbose", "v", false, "write the output of every simulation")
+	rootCmd.AddCommand(testCmd)
+}
+
+// testScenarios runs the scenario files and directories given, reporting every scenario to out,
+// returns the number of scenarios which failed and the number of scenarios run
+func testScenarios(ctx context.Context, paths []string, out, simulationOut io.Writer) (int, int, error) {
+	files := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		dirFiles, err := scenario.Files(path)
+		if err != nil {
+			return 0, 0, err
+		}
+		files = append(files, dirFiles...)
+	}
+
+	failed := 0
+	for _, file := range files {
+		fmt.Fprintf(simulationOut, "RUN   %s\n", file)
+		report := scenario.Test(ctx, file, simulationOut)
+		if err := ctx.Err(); err != nil {
+			return failed, len(files), err
+		}
+
+		switch {
+		case report.Err != nil:
+			failed++
+			fmt.Fprintf(out, "ERROR %s: %v\n", file, report.Err)
+		case !report.Passed():
+			failed++
+			fmt.Fprintf(out, "FAIL  %s\n", file)
+			for _, failure := range report.Failures {
+				fmt.Fprintf(out, "      %s\n", failure)
+			}
+		default:
+			fmt.Fprintf(out, "PASS  %s (%d expectations)\n", file, report.Expectations)
+		}
+	}
+
+	fmt.Fprintf(out, "%d scenarios, %d passed, %d failed\n", len(files), len(files)-failed, failed)
+	return failed, len(files), nil
+}
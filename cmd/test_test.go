@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_testScenarios(t *testing.T) {
+	ctx := context.Background()
+
+	out := &bytes.Buffer{}
+	failed, total, err := testScenarios(ctx, []string{"../test_data/scenarios"}, out, io.Discard)
+	require.NoError(t, err)
+	require.Zero(t, failed)
+	require.Equal(t, 3, total)
+	require.Contains(t, out.String(), "PASS  ../test_data/scenarios/duel.yaml (6 expectations)\n")
+	require.Contains(t, out.String(), "3 scenarios, 3 passed, 0 failed\n")
+
+	failing := filepath.Join(t.TempDir(), "failing.yaml")
+	require.NoError(t, os.WriteFile(failing, []byte("version: 1\nmap:\n  inline: A\naliens: 1\nexpect:\n  termination: max_moves\n"), 0o644))
+
+	out.Reset()
+	failed, total, err = testScenarios(ctx, []string{failing, "../test_data/scenarios/duel.yaml"}, out, io.Discard)
+	require.NoError(t, err)
+	require.Equal(t, 1, failed)
+	require.Equal(t, 2, total)
+	require.Contains(t, out.String(), "FAIL  "+failing+"\n      termination: want max_moves, got stalemate\n")
+
+	_, _, err = testScenarios(ctx, []string{"../test_data/missing"}, out, io.Discard)
+	require.Error(t, err)
+}
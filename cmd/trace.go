@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"alien-invasion-cc/trace"
+)
+
+// traceToFile traces the spans started from the context retrieved into a file, as JSON lines;
+// the function retrieved writes the spans and closes the file
+func traceToFile(ctx context.Context, path string) (context.Context, func() error, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	exporter := trace.NewFileExporter(f)
+	ctx = trace.ContextWithTracer(ctx, trace.NewTracer(exporter))
+
+	return ctx, func() error {
+		err := exporter.Flush()
+		closeErr := f.Close()
+		if err != nil {
+			return err
+		}
+		return closeErr
+	}, nil
+}
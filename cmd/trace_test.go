@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+	"alien-invasion-cc/trace"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_traceToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+
+	ctx, closeTrace, err := traceToFile(context.Background(), path)
+	require.NoError(t, err)
+
+	in, err := os.Open("../test_data/test_map")
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	seed := int64(42)
+	err = runEngine(ctx, &config{
+		numAliens:  4,
+		maxMoves:   100,
+		in:         in,
+		out:        &bytes.Buffer{},
+		directions: types.ClassicDirections,
+		mapFormat:  mapfile.FormatClassic,
+		seed:       &seed,
+	})
+	require.NoError(t, err)
+	require.NoError(t, closeTrace())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	names := map[string]int{}
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		span := trace.SpanData{}
+		require.NoError(t, json.Unmarshal([]byte(line), &span))
+		names[span.Name]++
+	}
+	require.Equal(t, 1, names["Engine.Run"])
+	require.Equal(t, 1, names["Engine.loadWorld"])
+	require.NotZero(t, names["Engine.DoNextMove"])
+
+	_, _, err = traceToFile(context.Background(), filepath.Join(t.TempDir(), "missing", "trace.jsonl"))
+	require.Error(t, err)
+}
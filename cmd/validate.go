@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"alien-invasion-cc/engine/types"
+)
+
+// validateCmd checks maps load without error
+var validateCmd = &cobra.Command{
+	Use:   "validate [mapfile]...",
+	Short: "Check maps load without error, reporting their cities and roads",
+	Long: `Check maps load without error, reporting their cities and roads.
+
+The maps are loaded as a run would, with --format, --directions and --two-way, every map given
+as argument on its own; the maps given by --file are checked when no map is given as argument,
+merged into one world as a run would merge them.`,
+	ValidArgsFunction: completeMapFiles,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		maps := [][]string{mapFiles}
+		if len(args) > 0 {
+			maps = make([][]string, len(args))
+			for i, path := range args {
+				maps[i] = []string{path}
+			}
+		}
+
+		directions, err := types.GetDirectionProfile(directionProfile)
+		if err != nil {
+			return err
+		}
+
+		invalid := validateMaps(cmd.Context(), maps, cmd.OutOrStdout(), directions, cmd.InOrStdin())
+		if invalid > 0 {
+			// the errors were reported, the command was not misused
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d of %d maps are invalid", invalid, len(maps))
+		}
+		return nil
+	},
+}
+
+func init() {
+	addMapFlags(validateCmd, validateCmd.Flags())
+	rootCmd.AddCommand(validateCmd)
+}
+
+// validateMaps loads the maps, each made of one file or of several files merged, reporting every map to out,
+// returns the number of maps which failed to load
+func validateMaps(ctx context.Context, maps [][]string, out io.Writer, directions *types.DirectionProfile, stdin io.Reader) int {
+	invalid := 0
+	for _, paths := range maps {
+		path := strings.Join(paths, " + ")
+		cities, roads, oneWay, err := countMap(ctx, paths, directions, stdin)
+		if err != nil {
+			invalid++
+			fmt.Fprintf(out, "FAIL  %s: %v\n", path, err)
+			continue
+		}
+
+		fmt.Fprintf(out, "OK    %s: %d cities, %d roads, %d one-way\n", path, cities, roads, oneWay)
+	}
+	return invalid
+}
+
+// countMap loads a map, retrieves its number of cities, roads and one-way roads
+func countMap(ctx context.Context, paths []string, directions *types.DirectionProfile, stdin io.Reader) (int, int, int, error) {
+	world, err := loadMapFiles(ctx, paths, mapFormat, directions, twoWayRoads, stdin)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	cities, err := world.GetCities(ctx)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	if len(cities) == 0 {
+		return 0, 0, 0, fmt.Errorf("the map has no city")
+	}
+
+	roads, err := world.GetRoads(ctx)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	oneWay := 0
+	for _, road := range roads {
+		if !road.TwoWay {
+			oneWay++
+		}
+	}
+
+	return len(cities), len(roads), oneWay, nil
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateMaps(t *testing.T) {
+	ctx := context.Background()
+
+	invalidMap := filepath.Join(t.TempDir(), "invalid.map")
+	require.NoError(t, os.WriteFile(invalidMap, []byte("Foo up=Bar\n"), 0o644))
+
+	out := &bytes.Buffer{}
+	invalid := validateMaps(ctx, [][]string{{"../test_data/test_map"}, {"../test_data/test_map.json"}, {invalidMap}, {"../test_data/missing"}}, out, types.ClassicDirections, nil)
+	require.Equal(t, 2, invalid)
+	require.Contains(t, out.String(), "OK    ../test_data/test_map: 10 cities, 12 roads, 9 one-way\n")
+	require.Contains(t, out.String(), "OK    ../test_data/test_map.json: 5 cities, 4 roads, 1 one-way\n")
+	require.Contains(t, out.String(), "FAIL  "+invalidMap+": ")
+	require.Contains(t, out.String(), "FAIL  ../test_data/missing: ")
+
+	out.Reset()
+	empty := filepath.Join(t.TempDir(), "empty.yaml")
+	require.NoError(t, os.WriteFile(empty, []byte("cities: []\n"), 0o644))
+	require.Equal(t, 1, validateMaps(ctx, [][]string{{empty}}, out, types.ClassicDirections, nil))
+	require.Equal(t, "FAIL  "+empty+": the map has no city\n", out.String())
+}
@@ -0,0 +1,163 @@
+// Package config reads configuration files and environment variables, as values keyed by flag name
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix starts the name of every environment variable read
+const EnvPrefix = "ALIEN_"
+
+var (
+	ERR_UNKNOWN_CONFIG_FORMAT error = fmt.Errorf("unknown configuration file format, expected .yaml, .yml or .toml")
+
+	ERR_INVALID_CONFIG_VALUE error = fmt.Errorf("invalid configuration value")
+)
+
+// Values Type definition, configuration values by key, the key of a flag of a subcommand being prefixed with the
+// subcommand names, e.g. serve.addr
+type Values map[string]Value
+
+// Value Type definition, a configuration value: the text of a scalar, or the items of a list kept apart
+type Value struct {
+	// Text of a scalar, empty for a list
+	Text string
+	// Items of a list, nil for a scalar
+	Items []string
+}
+
+// Scalar creates the value of a string, a number or a boolean
+func Scalar(text string) Value {
+	return Value{Text: text}
+}
+
+// List creates the value of a list, empty when given no item
+func List(items ...string) Value {
+	return Value{Items: append([]string{}, items...)}
+}
+
+// IsList checks if the value is a list
+func (v Value) IsList() bool {
+	return v.Items != nil
+}
+
+// String output of Value
+func (v Value) String() string {
+	if v.IsList() {
+		return fmt.Sprintf("%q", v.Items)
+	}
+	return v.Text
+}
+
+// Load reads a YAML or TOML configuration file, its format given by its extension
+func Load(path string) (Values, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = in.Close() }()
+
+	var values Values
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		values, err = DecodeYAML(in)
+	case ".toml":
+		values, err = DecodeTOML(in)
+	default:
+		return nil, fmt.Errorf("%w: %s", ERR_UNKNOWN_CONFIG_FORMAT, path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+// DecodeYAML reads YAML configuration values, a mapping named after a subcommand holding the values of its flags
+func DecodeYAML(in io.Reader) (Values, error) {
+	document := map[string]interface{}{}
+	err := yaml.NewDecoder(in).Decode(&document)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	values := Values{}
+	err = values.flatten("", document)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// flatten adds nested values, their keys prefixed with the keys of the mappings holding them
+func (v Values) flatten(prefix string, document map[string]interface{}) error {
+	for name, value := range document {
+		key := Key(prefix, name)
+
+		switch value := value.(type) {
+		case map[string]interface{}:
+			err := v.flatten(key, value)
+			if err != nil {
+				return err
+			}
+		case []interface{}:
+			items := make([]string, len(value))
+			for i, item := range value {
+				if !isScalar(item) {
+					return fmt.Errorf("%w: %s lists something else than strings, numbers or booleans", ERR_INVALID_CONFIG_VALUE, key)
+				}
+				items[i] = fmt.Sprint(item)
+			}
+			v[key] = List(items...)
+		case nil:
+			v[key] = Scalar("")
+		default:
+			if !isScalar(value) {
+				return fmt.Errorf("%w: %s", ERR_INVALID_CONFIG_VALUE, key)
+			}
+			v[key] = Scalar(fmt.Sprint(value))
+		}
+	}
+	return nil
+}
+
+// isScalar checks if a decoded value is a string, a number or a boolean
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, int, int64, uint64, float64, bool:
+		return true
+	}
+	return false
+}
+
+// Key retrieves the key of a value nested under a prefix, lower case with dashes between words,
+// road_fights and road-fights being the same key
+func Key(prefix, name string) string {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", "-")
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// EnvName retrieves the name of the environment variable holding a value, e.g. ALIEN_ROAD_FIGHTS or ALIEN_SERVE_ADDR
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// Keys retrieves the keys of the values, sorted
+func (v Values) Keys() []string {
+	keys := make([]string, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
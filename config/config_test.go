@@ -0,0 +1,55 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ciValues are the values of the CI configuration files
+var ciValues = Values{
+	"aliens":                Scalar("4"),
+	"steps":                 Scalar("50"),
+	"seed":                  Scalar("1"),
+	"road-fights":           Scalar("true"),
+	"serve.addr":            Scalar(":9090"),
+	"serve.allowed-origins": List("https://a.example", "https://b.example"),
+	"analyze.output":        Scalar("json"),
+	"analyze.path.fastest":  Scalar("true"),
+}
+
+func Test_Load(t *testing.T) {
+	for _, path := range []string{"../test_data/config/ci.yaml", "../test_data/config/ci.toml"} {
+		values, err := Load(path)
+		require.NoError(t, err, path)
+		require.Equal(t, ciValues, values, path)
+	}
+
+	_, err := Load("../test_data/test_map.json")
+	require.ErrorIs(t, err, ERR_UNKNOWN_CONFIG_FORMAT)
+
+	_, err = Load("../test_data/config/missing.yaml")
+	require.Error(t, err)
+}
+
+func Test_DecodeYAML(t *testing.T) {
+	values, err := DecodeYAML(strings.NewReader(""))
+	require.NoError(t, err)
+	require.Empty(t, values)
+
+	values, err = DecodeYAML(strings.NewReader("timeout: 30s\nmetrics-addr:\n"))
+	require.NoError(t, err)
+	require.Equal(t, Values{"timeout": Scalar("30s"), "metrics-addr": Scalar("")}, values)
+
+	_, err = DecodeYAML(strings.NewReader("serve:\n  allowed-origins:\n    - {host: a}\n"))
+	require.ErrorIs(t, err, ERR_INVALID_CONFIG_VALUE)
+}
+
+func Test_Key(t *testing.T) {
+	require.Equal(t, "road-fights", Key("", "Road_Fights"))
+	require.Equal(t, "analyze.path.fastest", Key("analyze.path", "fastest"))
+	require.Equal(t, "ALIEN_ROAD_FIGHTS", EnvName("road-fights"))
+	require.Equal(t, "ALIEN_SERVE_MAX_EVENTS", EnvName("serve.max-events"))
+	require.Equal(t, []string{"aliens", "analyze.output", "analyze.path.fastest"}, Values{"analyze.path.fastest": Value{}, "aliens": Value{}, "analyze.output": Value{}}.Keys())
+}
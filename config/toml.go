@@ -0,0 +1,193 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var ERR_PARSE_TOML error = fmt.Errorf("error parsing the TOML configuration")
+
+// DecodeTOML reads TOML configuration values, a table named after a subcommand holding the values of its flags.
+// Keys and values stand on a single line: strings, numbers, booleans and arrays of them; dates, inline tables,
+// arrays of tables and multi-line strings are not read
+func DecodeTOML(in io.Reader) (Values, error) {
+	values := Values{}
+	table := ""
+
+	scanner := bufio.NewScanner(in)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(stripComment(scanner.Text()))
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			if strings.HasPrefix(text, "[[") || !strings.HasSuffix(text, "]") {
+				return nil, fmt.Errorf("%w: line %d: unsupported table %s", ERR_PARSE_TOML, line, text)
+			}
+
+			names, err := splitKey(text[1 : len(text)-1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ERR_PARSE_TOML, line, err)
+			}
+			table = strings.Join(names, ".")
+			continue
+		}
+
+		equal := strings.Index(text, "=")
+		if equal < 0 {
+			return nil, fmt.Errorf("%w: line %d: expected key = value", ERR_PARSE_TOML, line)
+		}
+
+		names, err := splitKey(text[:equal])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ERR_PARSE_TOML, line, err)
+		}
+
+		value, err := parseTOMLValue(strings.TrimSpace(text[equal+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ERR_PARSE_TOML, line, err)
+		}
+
+		key := table
+		for _, name := range names {
+			key = Key(key, name)
+		}
+		values[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// stripComment removes the comment ending a line, a # within a string being kept
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote == 0 && c == '#':
+			return line[:i]
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == '"' && c == '\\':
+			i++
+		case c == quote:
+			quote = 0
+		}
+	}
+	return line
+}
+
+// splitKey splits a dotted key, bare or quoted, into its names
+func splitKey(key string) ([]string, error) {
+	names := []string{}
+	for _, part := range strings.Split(key, ".") {
+		name := strings.TrimSpace(part)
+		if len(name) >= 2 && (name[0] == '"' || name[0] == '\'') && name[len(name)-1] == name[0] {
+			name = name[1 : len(name)-1]
+		}
+
+		if name == "" || strings.ContainsAny(name, " \t\"'=[]") {
+			return nil, fmt.Errorf("invalid key %q", strings.TrimSpace(key))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// parseTOMLValue parses a value, the items of an array being kept apart
+func parseTOMLValue(text string) (Value, error) {
+	if !strings.HasPrefix(text, "[") {
+		scalar, err := parseTOMLScalar(text)
+		if err != nil {
+			return Value{}, err
+		}
+		return Scalar(scalar), nil
+	}
+
+	if !strings.HasSuffix(text, "]") {
+		return Value{}, fmt.Errorf("unterminated array %s", text)
+	}
+
+	items := []string{}
+	for _, item := range splitArray(text[1 : len(text)-1]) {
+		scalar, err := parseTOMLScalar(item)
+		if err != nil {
+			return Value{}, err
+		}
+		items = append(items, scalar)
+	}
+	return List(items...), nil
+}
+
+// parseTOMLScalar parses a string, a number or a boolean into the text a flag is set with
+func parseTOMLScalar(text string) (string, error) {
+	switch {
+	case strings.HasPrefix(text, `"`):
+		value, err := strconv.Unquote(text)
+		if err != nil {
+			return "", fmt.Errorf("invalid string %s", text)
+		}
+		return value, nil
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") || strings.Contains(text[1:len(text)-1], "'") {
+			return "", fmt.Errorf("invalid string %s", text)
+		}
+		return text[1 : len(text)-1], nil
+	case text == "true" || text == "false":
+		return text, nil
+	}
+
+	number := strings.ReplaceAll(text, "_", "")
+	if hasLeadingZero(number) {
+		return "", fmt.Errorf("invalid number %s, leading zeros are not allowed", text)
+	}
+
+	if _, err := strconv.ParseInt(number, 0, 64); err == nil {
+		return number, nil
+	}
+
+	if _, err := strconv.ParseFloat(number, 64); err == nil {
+		return number, nil
+	}
+
+	return "", fmt.Errorf("unsupported value %s", text)
+}
+
+// hasLeadingZero checks if a decimal number starts with a zero followed by digits, which TOML forbids and
+// flags would read as octal
+func hasLeadingZero(number string) bool {
+	number = strings.TrimLeft(number, "+-")
+	return len(number) > 1 && number[0] == '0' && number[1] >= '0' && number[1] <= '9'
+}
+
+// splitArray splits the items of an array at the commas outside strings, a trailing comma being allowed
+func splitArray(text string) []string {
+	items := []string{}
+	var quote byte
+	start := 0
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == '"' && c == '\\':
+			i++
+		case c == quote:
+			quote = 0
+		case quote == 0 && c == ',':
+			items = append(items, strings.TrimSpace(text[start:i]))
+			start = i + 1
+		}
+	}
+
+	if last := strings.TrimSpace(text[start:]); last != "" {
+		items = append(items, last)
+	}
+	return items
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DecodeTOML(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Values
+		errText string
+	}{
+		{
+			name: "scalars",
+			in:   "aliens = 1_000\nratio = 0.5\nname = \"a \\\"quoted\\\" # name\"\npath = 'C:\\maps'\n",
+			want: Values{"aliens": Scalar("1000"), "ratio": Scalar("0.5"), "name": Scalar(`a "quoted" # name`), "path": Scalar(`C:\maps`)},
+		},
+		{
+			name: "dotted and quoted keys",
+			in:   "serve.addr = \":80\"\n[analyze]\n\"path\".fastest = true\n",
+			want: Values{"serve.addr": Scalar(":80"), "analyze.path.fastest": Scalar("true")},
+		},
+		{
+			name: "arrays",
+			in:   "origins = [ \"a, b\", 'c', ]\nempty = []\n",
+			want: Values{"origins": List("a, b", "c"), "empty": List()},
+		},
+		{
+			name: "numbers",
+			in:   "zero = 0\nnegative = -1\nhex = 0x1F\nfraction = 0.5\n",
+			want: Values{"zero": Scalar("0"), "negative": Scalar("-1"), "hex": Scalar("0x1F"), "fraction": Scalar("0.5")},
+		},
+		{
+			name:    "leading zero",
+			in:      "aliens = 010\n",
+			errText: "error parsing the TOML configuration: line 1: invalid number 010, leading zeros are not allowed",
+		},
+		{
+			name:    "nested array",
+			in:      "origins = [[\"a\"]]\n",
+			errText: `error parsing the TOML configuration: line 1: unsupported value ["a"]`,
+		},
+		{
+			name:    "array of tables",
+			in:      "[[maps]]\n",
+			errText: "error parsing the TOML configuration: line 1: unsupported table [[maps]]",
+		},
+		{
+			name:    "missing value",
+			in:      "# comment\naliens\n",
+			errText: "error parsing the TOML configuration: line 2: expected key = value",
+		},
+		{
+			name:    "date",
+			in:      "since = 2022-01-01\n",
+			errText: "error parsing the TOML configuration: line 1: unsupported value 2022-01-01",
+		},
+		{
+			name:    "invalid key",
+			in:      "road fights = true\n",
+			errText: `error parsing the TOML configuration: line 1: invalid key "road fights"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := DecodeTOML(strings.NewReader(tt.in))
+			if tt.errText != "" {
+				require.ErrorIs(t, err, ERR_PARSE_TOML)
+				require.EqualError(t, err, tt.errText)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, values)
+		})
+	}
+}
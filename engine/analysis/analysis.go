@@ -0,0 +1,374 @@
+// Package analysis computes graph analytics over the cities of a world and the roads between them
+package analysis
+
+import (
+	"context"
+	"sort"
+
+	"alien-invasion-cc/engine/types"
+)
+
+// DefaultTop is the number of most central cities reported by default
+const DefaultTop = 10
+
+// CityLister lists the cities of a world still standing, in the order they were added
+type CityLister interface {
+	GetAliveCities(ctx context.Context) ([]*types.City, error)
+}
+
+// Component Type definition, a connected part of the map
+type Component struct {
+	// Number of cities
+	Size int `json:"size"`
+	// Cities of the component, in map order
+	Cities []string `json:"cities"`
+}
+
+// DegreeCount Type definition, the number of cities with a given degree
+type DegreeCount struct {
+	// Number of roads
+	Degree int `json:"degree"`
+	// Number of cities with that many roads
+	Cities int `json:"cities"`
+}
+
+// Bridge Type definition, a road whose destruction splits its component
+type Bridge struct {
+	// City the road links, first in map order
+	From string `json:"from"`
+	// Other city the road links
+	To string `json:"to"`
+}
+
+// Centrality Type definition, the betweenness centrality of a city
+type Centrality struct {
+	// City name
+	City string `json:"city"`
+	// Number of shortest paths between two other cities going through the city, split between equally short paths
+	Betweenness float64 `json:"betweenness"`
+}
+
+// Report Type definition, the analytics of a map
+type Report struct {
+	// Number of cities standing
+	Cities int `json:"cities"`
+	// Number of roads, a road being counted once for each way it can be travelled
+	Roads int `json:"roads"`
+	// Number of roads with no way back
+	AsymmetricRoads int `json:"asymmetric_roads"`
+	// Connected components, largest first, roads being followed both ways
+	Components []Component `json:"components"`
+	// Distribution of the roads leading out of the cities
+	OutDegrees []DegreeCount `json:"out_degrees"`
+	// Distribution of the roads leading into the cities
+	InDegrees []DegreeCount `json:"in_degrees"`
+	// Longest shortest path between two cities of the same component, in roads
+	Diameter int `json:"diameter"`
+	// Cities whose destruction splits their component, in map order
+	ArticulationPoints []string `json:"articulation_points"`
+	// Roads whose destruction splits their component, in map order
+	Bridges []Bridge `json:"bridges"`
+	// Most central cities, most central first
+	Betweenness []Centrality `json:"betweenness"`
+}
+
+// Option configures an analysis
+type Option func(*analyzer)
+
+// WithTop sets the number of most central cities reported, DefaultTop by default
+func WithTop(top int) Option {
+	return func(a *analyzer) {
+		if top >= 0 {
+			a.top = top
+		}
+	}
+}
+
+// analyzer holds the map as an undirected graph of city indexes
+type analyzer struct {
+	top int
+
+	cities []*types.City
+
+	// neighbours of every city, roads being followed both ways
+	neighbours [][]int
+}
+
+// Analyze computes the analytics of the cities of a world standing;
+// betweenness and diameter take a breadth-first search from every city, hence a time in cities times roads
+func Analyze(ctx context.Context, world CityLister, opts ...Option) (*Report, error) {
+	cities, err := world.GetAliveCities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &analyzer{top: DefaultTop}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	report := a.load(cities)
+	report.Components = a.components()
+
+	err = a.betweenness(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+
+	a.cutPoints(report)
+	return report, nil
+}
+
+// load builds the undirected graph, counts the roads and the degrees
+func (a *analyzer) load(cities []*types.City) *Report {
+	a.cities = cities
+	a.neighbours = make([][]int, len(cities))
+
+	index := make(map[*types.City]int, len(cities))
+	for i, city := range cities {
+		index[city] = i
+	}
+
+	report := &Report{Cities: len(cities)}
+	outDegrees := make([]int, len(cities))
+	inDegrees := make([]int, len(cities))
+	linked := make([]map[int]bool, len(cities))
+	for i := range linked {
+		linked[i] = make(map[int]bool)
+	}
+
+	for i, city := range cities {
+		for _, to := range city.GetAvailableLinks() {
+			j, found := index[to]
+			if !found {
+				continue
+			}
+
+			report.Roads++
+			outDegrees[i]++
+			inDegrees[j]++
+			linked[i][j] = true
+		}
+	}
+
+	for i := range cities {
+		for j := range linked[i] {
+			if !linked[j][i] {
+				report.AsymmetricRoads++
+			}
+
+			// an edge is added once, from its end first in map order or from its only end
+			if i < j || !linked[j][i] {
+				a.neighbours[i] = append(a.neighbours[i], j)
+				a.neighbours[j] = append(a.neighbours[j], i)
+			}
+		}
+	}
+
+	for i := range a.neighbours {
+		sort.Ints(a.neighbours[i])
+	}
+
+	report.OutDegrees = distribution(outDegrees)
+	report.InDegrees = distribution(inDegrees)
+	return report
+}
+
+// distribution counts the cities of every degree, by increasing degree
+func distribution(degrees []int) []DegreeCount {
+	counts := make(map[int]int)
+	for _, degree := range degrees {
+		counts[degree]++
+	}
+
+	result := make([]DegreeCount, 0, len(counts))
+	for degree, cities := range counts {
+		result = append(result, DegreeCount{Degree: degree, Cities: cities})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Degree < result[j].Degree })
+	return result
+}
+
+// components computes the connected components, largest first, then in map order of their first city
+func (a *analyzer) components() []Component {
+	seen := make([]bool, len(a.cities))
+	components := []Component{}
+
+	for start := range a.cities {
+		if seen[start] {
+			continue
+		}
+
+		seen[start] = true
+		members := []int{start}
+		for queue := []int{start}; len(queue) > 0; queue = queue[1:] {
+			for _, next := range a.neighbours[queue[0]] {
+				if !seen[next] {
+					seen[next] = true
+					members = append(members, next)
+					queue = append(queue, next)
+				}
+			}
+		}
+
+		sort.Ints(members)
+		component := Component{Size: len(members), Cities: make([]string, len(members))}
+		for i, member := range members {
+			component.Cities[i] = a.cities[member].Name
+		}
+		components = append(components, component)
+	}
+
+	sort.SliceStable(components, func(i, j int) bool { return components[i].Size > components[j].Size })
+	return components
+}
+
+// betweenness computes the betweenness centrality of every city with Brandes' algorithm, and the diameter along the way
+func (a *analyzer) betweenness(ctx context.Context, report *Report) error {
+	n := len(a.cities)
+	scores := make([]float64, n)
+
+	distances := make([]int, n)
+	paths := make([]float64, n)
+	dependencies := make([]float64, n)
+	predecessors := make([][]int, n)
+	order := make([]int, 0, n)
+
+	for source := 0; source < n; source++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		for i := range distances {
+			distances[i] = -1
+			paths[i] = 0
+			dependencies[i] = 0
+			predecessors[i] = predecessors[i][:0]
+		}
+		order = order[:0]
+
+		distances[source] = 0
+		paths[source] = 1
+		for queue := []int{source}; len(queue) > 0; queue = queue[1:] {
+			current := queue[0]
+			order = append(order, current)
+
+			if distances[current] > report.Diameter {
+				report.Diameter = distances[current]
+			}
+
+			for _, next := range a.neighbours[current] {
+				if distances[next] < 0 {
+					distances[next] = distances[current] + 1
+					queue = append(queue, next)
+				}
+
+				if distances[next] == distances[current]+1 {
+					paths[next] += paths[current]
+					predecessors[next] = append(predecessors[next], current)
+				}
+			}
+		}
+
+		// dependencies are accumulated from the farthest cities back to the source
+		for i := len(order) - 1; i > 0; i-- {
+			current := order[i]
+			for _, previous := range predecessors[current] {
+				dependencies[previous] += paths[previous] / paths[current] * (1 + dependencies[current])
+			}
+			scores[current] += dependencies[current]
+		}
+	}
+
+	centralities := make([]Centrality, n)
+	for i, city := range a.cities {
+		// every pair of cities is met from both ends
+		centralities[i] = Centrality{City: city.Name, Betweenness: scores[i] / 2}
+	}
+
+	sort.SliceStable(centralities, func(i, j int) bool { return centralities[i].Betweenness > centralities[j].Betweenness })
+	if len(centralities) > a.top {
+		centralities = centralities[:a.top]
+	}
+
+	report.Betweenness = centralities
+	return nil
+}
+
+// cutPoints computes the articulation points and the bridges with Tarjan's algorithm
+func (a *analyzer) cutPoints(report *Report) {
+	n := len(a.cities)
+	discovered := make([]int, n)
+	low := make([]int, n)
+	isArticulation := make([]bool, n)
+	bridges := []Bridge{}
+	clock := 0
+
+	var visit func(current, parent int)
+	visit = func(current, parent int) {
+		clock++
+		discovered[current], low[current] = clock, clock
+
+		children := 0
+		for _, next := range a.neighbours[current] {
+			if next == parent {
+				continue
+			}
+
+			if discovered[next] > 0 {
+				if discovered[next] < low[current] {
+					low[current] = discovered[next]
+				}
+				continue
+			}
+
+			children++
+			visit(next, current)
+			if low[next] < low[current] {
+				low[current] = low[next]
+			}
+
+			if parent >= 0 && low[next] >= discovered[current] {
+				isArticulation[current] = true
+			}
+
+			if low[next] > discovered[current] {
+				from, to := current, next
+				if from > to {
+					from, to = to, from
+				}
+				bridges = append(bridges, Bridge{From: a.cities[from].Name, To: a.cities[to].Name})
+			}
+		}
+
+		if parent < 0 && children > 1 {
+			isArticulation[current] = true
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if discovered[i] == 0 {
+			visit(i, -1)
+		}
+	}
+
+	index := make(map[string]int, n)
+	for i, city := range a.cities {
+		index[city.Name] = i
+	}
+	sort.Slice(bridges, func(i, j int) bool {
+		if bridges[i].From != bridges[j].From {
+			return index[bridges[i].From] < index[bridges[j].From]
+		}
+		return index[bridges[i].To] < index[bridges[j].To]
+	})
+
+	report.ArticulationPoints = []string{}
+	for i, articulation := range isArticulation {
+		if articulation {
+			report.ArticulationPoints = append(report.ArticulationPoints, a.cities[i].Name)
+		}
+	}
+	report.Bridges = bridges
+}
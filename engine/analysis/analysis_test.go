@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"alien-invasion-cc/engine"
+)
+
+// loadWorld loads a map into a world with no alien
+func loadWorld(t *testing.T, in io.Reader) engine.World {
+	e := engine.NewEngine(0, 0, in, io.Discard)
+	require.NoError(t, e.LoadEngine(context.Background()))
+	return e.World()
+}
+
+func Test_Analyze(t *testing.T) {
+	in, err := os.Open("../../test_data/test_map")
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	report, err := Analyze(context.Background(), loadWorld(t, in), WithTop(3))
+	require.NoError(t, err)
+
+	require.Equal(t, 10, report.Cities)
+	require.Equal(t, 15, report.Roads)
+	require.Equal(t, 5, report.AsymmetricRoads)
+	require.Equal(t, []Component{
+		{Size: 9, Cities: []string{"Paris", "Berlin", "Barcelona", "London", "Stockholm", "Warsaw", "Rome", "Brussels", "Geneva"}},
+		{Size: 1, Cities: []string{"Athens"}},
+	}, report.Components)
+	require.Equal(t, []DegreeCount{{0, 3}, {1, 2}, {2, 3}, {3, 1}, {4, 1}}, report.OutDegrees)
+	require.Equal(t, []DegreeCount{{0, 1}, {1, 3}, {2, 6}}, report.InDegrees)
+
+	// London to Stockholm, through Paris and Berlin, or Geneva to Brussels
+	require.Equal(t, 4, report.Diameter)
+
+	// Paris holds London and Brussels, the rest lies on a cycle
+	require.Equal(t, []string{"Paris"}, report.ArticulationPoints)
+	require.Equal(t, []Bridge{{From: "Paris", To: "London"}, {From: "Paris", To: "Brussels"}}, report.Bridges)
+
+	require.Equal(t, []Centrality{{"Paris", 16}, {"Berlin", 9}, {"Barcelona", 5}}, report.Betweenness)
+}
+
+func Test_Analyze_Shapes(t *testing.T) {
+	tests := []struct {
+		name, input        string
+		wantDiameter       int
+		wantArticulations  []string
+		wantBridges        []Bridge
+		wantBetweenness    []Centrality
+		wantAsymmetricRoad int
+	}{
+		{
+			name:              "Path",
+			input:             "A east=B\nB east=C west=A\nC west=B\n",
+			wantDiameter:      2,
+			wantArticulations: []string{"B"},
+			wantBridges:       []Bridge{{"A", "B"}, {"B", "C"}},
+			wantBetweenness:   []Centrality{{"B", 1}, {"A", 0}, {"C", 0}},
+		},
+		{
+			name:              "Cycle",
+			input:             "A east=B\nB east=C\nC east=D\nD east=A\n",
+			wantDiameter:      2,
+			wantArticulations: []string{},
+			wantBridges:       []Bridge{},
+			// the opposite cities are linked by two paths as short, each going through half
+			wantBetweenness:    []Centrality{{"A", 0.5}, {"B", 0.5}, {"C", 0.5}, {"D", 0.5}},
+			wantAsymmetricRoad: 4,
+		},
+		{
+			name:              "Empty",
+			input:             "",
+			wantDiameter:      0,
+			wantArticulations: []string{},
+			wantBridges:       []Bridge{},
+			wantBetweenness:   []Centrality{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Analyze(context.Background(), loadWorld(t, strings.NewReader(tt.input)))
+			require.NoError(t, err)
+			require.Equal(t, tt.wantDiameter, report.Diameter)
+			require.Equal(t, tt.wantArticulations, report.ArticulationPoints)
+			require.Equal(t, tt.wantBridges, report.Bridges)
+			require.Equal(t, tt.wantBetweenness, report.Betweenness)
+			require.Equal(t, tt.wantAsymmetricRoad, report.AsymmetricRoads)
+		})
+	}
+
+	// the search is cancelled with its context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Analyze(ctx, loadWorld(t, strings.NewReader("A east=B\n")))
+	require.ErrorIs(t, err, context.Canceled)
+}
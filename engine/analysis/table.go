@@ -0,0 +1,85 @@
+package analysis
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteTable writes the report as aligned text tables
+func (r *Report) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	largest := 0
+	if len(r.Components) > 0 {
+		largest = r.Components[0].Size
+	}
+
+	fmt.Fprintf(tw, "Cities\t%d\n", r.Cities)
+	fmt.Fprintf(tw, "Roads\t%d\n", r.Roads)
+	fmt.Fprintf(tw, "Asymmetric roads\t%d\n", r.AsymmetricRoads)
+	fmt.Fprintf(tw, "Components\t%d\n", len(r.Components))
+	fmt.Fprintf(tw, "Largest component\t%d\n", largest)
+	fmt.Fprintf(tw, "Diameter\t%d\n", r.Diameter)
+	fmt.Fprintf(tw, "Articulation points\t%d\n", len(r.ArticulationPoints))
+	fmt.Fprintf(tw, "Bridges\t%d\n", len(r.Bridges))
+
+	fmt.Fprintf(tw, "\nDegree\tOut\tIn\n")
+	out, in := degreeCounts(r.OutDegrees), degreeCounts(r.InDegrees)
+	for degree := 0; degree <= maxDegree(r.OutDegrees, r.InDegrees); degree++ {
+		if out[degree] == 0 && in[degree] == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%d\n", degree, out[degree], in[degree])
+	}
+
+	if len(r.Components) > 1 {
+		fmt.Fprintf(tw, "\nComponent\tCities\n")
+		for i, component := range r.Components {
+			fmt.Fprintf(tw, "%d\t%d\n", i+1, component.Size)
+		}
+	}
+
+	if len(r.ArticulationPoints) > 0 {
+		fmt.Fprintf(tw, "\nArticulation points\t%s\n", strings.Join(r.ArticulationPoints, ", "))
+	}
+
+	if len(r.Bridges) > 0 {
+		fmt.Fprintf(tw, "\nBridges\n")
+		for _, bridge := range r.Bridges {
+			fmt.Fprintf(tw, "%s - %s\n", bridge.From, bridge.To)
+		}
+	}
+
+	if len(r.Betweenness) > 0 {
+		fmt.Fprintf(tw, "\nCity\tBetweenness\n")
+		for _, centrality := range r.Betweenness {
+			fmt.Fprintf(tw, "%s\t%.2f\n", centrality.City, centrality.Betweenness)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// degreeCounts indexes a degree distribution by degree
+func degreeCounts(distribution []DegreeCount) map[int]int {
+	counts := make(map[int]int, len(distribution))
+	for _, count := range distribution {
+		counts[count.Degree] = count.Cities
+	}
+	return counts
+}
+
+// maxDegree retrieves the highest degree of distributions
+func maxDegree(distributions ...[]DegreeCount) int {
+	highest := 0
+	for _, distribution := range distributions {
+		for _, count := range distribution {
+			if count.Degree > highest {
+				highest = count.Degree
+			}
+		}
+	}
+	return highest
+}
@@ -0,0 +1,46 @@
+package analysis
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Report_WriteTable(t *testing.T) {
+	report, err := Analyze(context.Background(), loadWorld(t, strings.NewReader("A east=B\nB east=C west=A\nC west=B\nD\n")), WithTop(2))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, report.WriteTable(out))
+	require.Equal(t, `Cities               4
+Roads                4
+Asymmetric roads     0
+Components           2
+Largest component    3
+Diameter             2
+Articulation points  1
+Bridges              2
+
+Degree  Out  In
+0       1    1
+1       2    2
+2       1    1
+
+Component  Cities
+1          3
+2          1
+
+Articulation points  B
+
+Bridges
+A - B
+B - C
+
+City  Betweenness
+B     1.00
+A     0.00
+`, out.String())
+}
@@ -1,17 +1,19 @@
 package engine
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"strings"
+	"sort"
 	"math/rand"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
+	"alien-invasion-cc/engine/mapfile"
 	"alien-invasion-cc/engine/types"
+	"alien-invasion-cc/trace"
 )
 
 // Alien Invasion Engine type definition
@@ -28,6 +30,45 @@ type EngineImpl struct {
 
 	totalMoves uint
 
+	autoReverseRoads bool
+
+	directions *types.DirectionProfile
+
+	roadFights bool
+
+	mapFormat mapfile.Format
+
+	// reader the map is loaded from instead of the input, if any
+	mapReader mapfile.MapReader
+
+	streaming bool
+
+	parallel bool
+
+	loaderOptions []LoaderOption
+
+	random *rand.Rand
+
+	eventListener func(Event)
+
+	metrics *Metrics
+
+	reportPositions bool
+
+	reportFragmentation bool
+
+	// city every placed alien lands in, by alien ID
+	placement map[int]string
+
+	// connected components of the map loaded, before any alien lands
+	initialConnectivity *connectivity
+
+	termination TerminationReason
+
+	// connected components of the alive cities, computed for componentCities alive cities
+	components map[*types.City]int
+
+	componentCities int
 }
 
 var _ Engine = (*EngineImpl)(nil)
@@ -44,26 +85,70 @@ func GetRandInt(n int) (int, error) {
 	return r, nil
 }
 
-func NewEngine(numAliens, maxMoves uint, in io.Reader, out io.Writer) *EngineImpl {
+// randInt draws a random int in [0, n), from the seeded source if any
+func (s *EngineImpl) randInt(n int) (int, error) {
+	if s.random == nil {
+		return GetRandInt(n)
+	}
+
+	if n <= 0 {
+		return 0, types.ERR_RANDOM_OUT_OF_BOUNDS
+	}
+
+	return s.random.Intn(n), nil
+}
+
+func NewEngine(numAliens, maxMoves uint, in io.Reader, out io.Writer, opts ...Option) *EngineImpl {
 	world := NewWorld()
-	return &EngineImpl{
+	s := &EngineImpl{
 		world: 		world,
 		in: 		in,
 		out:		out,
 		maxMoves:	maxMoves,
 		numAliens:numAliens,
+		directions: types.ClassicDirections,
+		mapFormat: mapfile.FormatAuto,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // LoadEngine - spawn aliens, load world
 func (s *EngineImpl) LoadEngine(ctx context.Context) error {
 
-	err := s.loadWorld(ctx)
+	start := time.Now()
+	loadCtx, span := trace.Start(ctx, "Engine.loadWorld", trace.String("format", string(s.mapFormat)))
+	err := s.loadWorld(loadCtx)
+	span.RecordError(err)
+	span.End()
 	if err != nil {
 		return err
 	}
 
+	if s.metrics != nil {
+		err = s.observeMapLoad(ctx, start)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.reportFragmentation {
+		err = s.snapshotConnectivity(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	for i := 0; i < int(s.numAliens); i++ {
+		err = contextError(ctx)
+		if err != nil {
+			return err
+		}
+
 		alienID := i + 1
 		alien, err := s.world.AddAlien(ctx, alienID)
 		if err != nil {
@@ -71,6 +156,23 @@ func (s *EngineImpl) LoadEngine(ctx context.Context) error {
 		}
 
 		var nextCity *types.City
+		if cityName, found := s.placement[alienID]; found {
+			nextCity, err = s.world.GetCity(ctx, cityName)
+			if err != nil {
+				return err
+			}
+
+			if nextCity == nil {
+				return fmt.Errorf("%w: alien #%d can't land in %s", types.ERR_UNKNOWN_CITY, alienID, cityName)
+			}
+
+			_, err = s.moveAlienToCity(ctx, alien, nextCity)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		aliveCities, err := s.world.GetAliveCities(ctx)
 		if err != nil {
 			return err
@@ -80,7 +182,7 @@ func (s *EngineImpl) LoadEngine(ctx context.Context) error {
 			return nil
 		}
 
-		r, err := GetRandInt(len(aliveCities))
+		r, err := s.randInt(len(aliveCities))
 		if err != nil {
 			return err
 		}
@@ -99,35 +201,34 @@ func (s *EngineImpl) LoadEngine(ctx context.Context) error {
 // HasNextMove check if next move available 
 func (s *EngineImpl) HasNextMove(ctx context.Context) (bool, error) {
 
-	if s.totalMoves >= s.maxMoves {
-		return false, nil
-	}
-
-	untrappedAliens, err := s.world.GetUntrappedAliens(ctx)
+	reason, err := s.TerminationReason(ctx)
 	if err != nil {
 		return false, err
 	}
 
-	if len(untrappedAliens) == 0 {
-		return false, nil
-	}
+	return reason == TerminationNone, nil
+}
+
+// DoNextMove proceed next move of engine
+func (s *EngineImpl) DoNextMove(ctx context.Context) (err error) {
 
-	aliveCities, err := s.world.GetAliveCities(ctx)
+	// a step is never cut in half: the cancellation is checked before it starts, the aliens then all move
+	err = contextError(ctx)
 	if err != nil {
-		return false, err
+		return err
 	}
 
-	if len(aliveCities) == 0 {
-		return false, nil
+	if s.metrics != nil {
+		defer s.metrics.observeStep(time.Now())
 	}
 
-	return true, nil
-}
-
-// DoNextMove proceed next move of engine
-func (s *EngineImpl) DoNextMove(ctx context.Context) error {
-
 	s.totalMoves++
+	ctx, span := trace.Start(ctx, "Engine.DoNextMove", trace.Int("step", int(s.totalMoves)))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	untrappedAliens, err :=s.world.GetUntrappedAliens(ctx)
 	if err != nil {
 		return err
@@ -144,31 +245,160 @@ func (s *EngineImpl) DoNextMove(ctx context.Context) error {
 			continue
 		}
 
-		var nextCity *types.City
+		if alien.IsInTransit() {
+			err = s.travelAlien(ctx, alien)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		currentCity := alien.City
-		availableLinks := currentCity.GetAvailableLinks()
-		if len(availableLinks) > 0 {
-			r, err := GetRandInt(len(availableLinks))
+		if currentCity == nil {
+			continue
+		}
+
+		directions := currentCity.Directions()
+		if len(directions) > 0 {
+			r, err := s.randInt(len(directions))
+			if err != nil {
+				return err
+			}
+
+			direction := directions[r]
+			length, err := currentCity.GetLinkLength(direction)
 			if err != nil {
 				return err
 			}
 
-			i := 0
-			for _, city := range availableLinks {
-				if i == r {
-					nextCity = city
-					continue
+			if length > 1 {
+				err = s.world.SendAlien(ctx, alien, direction)
+				if err != nil {
+					return err
 				}
-				i++
+
+				s.emit(Event{Type: EventDeparted, Aliens: []int{alien.AlienID}, City: alien.Destination.Name, From: currentCity.Name, Road: alien.Road.String()})
+				continue
 			}
 
-			_, err = s.moveAlienToCity(ctx, alien, nextCity)
+			_, err = s.moveAlienToCity(ctx, alien, currentCity.Links[direction])
 			if err != nil {
 				return err
 			}
 		}
 	}
 
+	if s.roadFights {
+		return s.fightOnRoads(ctx)
+	}
+
+	return nil
+}
+
+// observeMapLoad reports the map loaded to the metrics
+func (s *EngineImpl) observeMapLoad(ctx context.Context, start time.Time) error {
+
+	cities, err := s.world.GetCities(ctx)
+	if err != nil {
+		return err
+	}
+
+	roads, err := s.world.GetRoads(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.metrics.observeMapLoad(start, len(cities), len(roads))
+	return nil
+}
+
+// travelAlien moves an alien one step further on its road, into its destination once reached
+func (s *EngineImpl) travelAlien(ctx context.Context, alien *types.Alien) error {
+
+	arrived, err := s.world.TravelAlien(ctx, alien)
+	if err != nil {
+		return err
+	}
+
+	if !arrived {
+		return nil
+	}
+
+	destination := alien.Destination
+	cityFound, err := s.world.GetCity(ctx, destination.Name)
+	if err != nil {
+		return err
+	}
+
+	// the destination was destroyed while travelling: the road leads nowhere any more
+	if cityFound != destination {
+		err = s.world.TrapAlien(ctx, alien)
+		if err != nil {
+			return err
+		}
+
+		s.emit(Event{Type: EventStranded, Aliens: []int{alien.AlienID}, City: destination.Name, Road: alien.Road.String()})
+		_, err = fmt.Fprintf(s.out, "%s is stranded on the road to destroyed %s\n", alien, destination.Name)
+		return err
+	}
+
+	_, err = s.moveAlienToCity(ctx, alien, destination)
+	return err
+}
+
+// fightOnRoads makes aliens travelling the same road in opposite ways fight each other
+func (s *EngineImpl) fightOnRoads(ctx context.Context) error {
+
+	aliens, err := s.world.GetAliensInTransit(ctx)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(aliens, func(i, j int) bool { return aliens[i].AlienID < aliens[j].AlienID })
+
+	for i, alien := range aliens {
+		if alien.IsTrapped {
+			continue
+		}
+
+		for _, other := range aliens[i+1:] {
+			if other.IsTrapped || other.Road != alien.Road || other.Destination == alien.Destination {
+				continue
+			}
+
+			err = s.fightOnRoad(ctx, alien, other)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintf(s.out, "%s and %s fought on the road %s\n", alien, other, alien.Road)
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+// fightOnRoad traps two aliens fighting on the road they travel
+func (s *EngineImpl) fightOnRoad(ctx context.Context, alien, other *types.Alien) error {
+
+	ctx, span := trace.Start(ctx, "Engine.roadFight", trace.Int("step", int(s.totalMoves)), trace.String("road", alien.Road.String()), trace.Ints("aliens", []int{alien.AlienID, other.AlienID}))
+	defer span.End()
+
+	err := s.world.TrapAlien(ctx, alien)
+	if err != nil {
+		return err
+	}
+
+	err = s.world.TrapAlien(ctx, other)
+	if err != nil {
+		return err
+	}
+
+	s.emit(Event{Type: EventRoadFight, Aliens: []int{alien.AlienID, other.AlienID}, Road: alien.Road.String()})
 	return nil
 }
 
@@ -183,8 +413,7 @@ func run(ctx context.Context, s Engine) error {
 	for {
 		select {
 		case <-ctx.Done():
-			log.Warn("Simulation cancelled")
-			return types.ERR_CONTEXT_CANCELLED
+			return cancelRun(ctx, s, contextError(ctx))
 		
 		default:
 			hasNextStep, err := s.HasNextMove(ctx)
@@ -197,6 +426,10 @@ func run(ctx context.Context, s Engine) error {
 			}
 
 			err = s.DoNextMove(ctx)
+			if isContextError(err) {
+				return cancelRun(ctx, s, err)
+			}
+
 			if err != nil {
 				return err
 			}
@@ -204,17 +437,82 @@ func run(ctx context.Context, s Engine) error {
 	}
 }
 
+// cancelRun reports the result reached by a cancelled simulation, retrieves the cancellation error
+func cancelRun(ctx context.Context, s Engine, err error) error {
+
+	log.Warn("Simulation cancelled")
+
+	// the world is in memory: the partial result is reported past the cancellation
+	finalizeErr := s.Finalize(ctx)
+	if finalizeErr != nil && !isContextError(finalizeErr) {
+		return finalizeErr
+	}
+
+	return err
+}
+
+// contextError retrieves an error wrapping the error of the context once done, nil until then
+func contextError(ctx context.Context) error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%v: %w", types.ERR_CONTEXT_CANCELLED, err)
+}
+
+// isContextError checks if an error comes from a cancelled context or an exceeded deadline
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
 
 func (s *EngineImpl) Run (ctx context.Context) error {
-	return run(ctx, s)
+	ctx, span := trace.Start(ctx, "Engine.Run", trace.Int("aliens", int(s.numAliens)), trace.Int("max_moves", int(s.maxMoves)))
+	defer span.End()
+
+	err := run(ctx, s)
+	span.RecordError(err)
+	span.SetAttributes(trace.Int("steps", int(s.totalMoves)))
+	return err
+}
+
+// World retrieves the world of the simulation
+func (s *EngineImpl) World() World {
+	return s.world
 }
 
 // Finalize engine finalize and output result
 func (s *EngineImpl) Finalize(ctx context.Context) error {
 
-	fmt.Fprintf(s.out, "\n===================\n")
-	fmt.Fprintf(s.out, "Simulation Finished\n")
-	fmt.Fprintf(s.out, "===================\n")
+	reason := TerminationCancelled
+	if ctx.Err() == nil {
+		var err error
+		reason, err = s.TerminationReason(ctx)
+		if err != nil {
+			return err
+		}
+
+		if reason == TerminationNone {
+			reason = TerminationStopped
+		}
+	}
+
+	s.termination = reason
+	s.emit(Event{Type: EventFinished, Reason: reason})
+
+	// a cancelled simulation reports the partial result it reached
+	if reason == TerminationCancelled {
+		fmt.Fprintf(s.out, "\n====================\n")
+		fmt.Fprintf(s.out, "Simulation Cancelled\n")
+		fmt.Fprintf(s.out, "====================\n")
+		fmt.Fprintf(s.out, "Steps Reached: %d/%d\n", s.totalMoves, s.maxMoves)
+	} else {
+		fmt.Fprintf(s.out, "\n===================\n")
+		fmt.Fprintf(s.out, "Simulation Finished\n")
+		fmt.Fprintf(s.out, "===================\n")
+		fmt.Fprintf(s.out, "Termination: %s\n", reason)
+	}
 
 	cities, err := s.world.GetAliveCities(ctx)
 
@@ -236,78 +534,182 @@ func (s *EngineImpl) Finalize(ctx context.Context) error {
 		}
 	}
 
+	aliensInTransit, err := s.world.GetAliensInTransit(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(aliensInTransit) > 0 {
+		sort.Slice(aliensInTransit, func(i, j int) bool { return aliensInTransit[i].AlienID < aliensInTransit[j].AlienID })
+
+		fmt.Fprintf(s.out, "\nAliens In Transit: %d\n\n", len(aliensInTransit))
+		for _, alien := range aliensInTransit {
+			_, err = fmt.Fprintf(s.out, "%s on the road to %s, %d steps left\n", alien, alien.Destination.Name, alien.StepsLeft)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = s.finalizeFragmentation(ctx)
+	if err != nil {
+		return err
+	}
+
+	if s.reportPositions {
+		return s.finalizePositions(ctx)
+	}
+
+	return nil
+}
+
+// finalizePositions outputs the city every untrapped alien stands in
+func (s *EngineImpl) finalizePositions(ctx context.Context) error {
+
+	untrappedAliens, err := s.world.GetUntrappedAliens(ctx)
+	if err != nil {
+		return err
+	}
+
+	var standing []*types.Alien
+	for _, alien := range untrappedAliens {
+		if alien.City != nil && !alien.IsInTransit() {
+			standing = append(standing, alien)
+		}
+	}
+
+	fmt.Fprintf(s.out, "\nAliens Standing: %d\n\n", len(standing))
+	for _, alien := range standing {
+		_, err = fmt.Fprintf(s.out, "%s in %s\n", alien, alien.City.Name)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 // loadWorld load City and City Link from Map Data
 func (s * EngineImpl) loadWorld(ctx context.Context) error {
 
-	registerCity := func(cityName string)(*types.City, error) {
-		city, err := s.world.GetCity(ctx, cityName)
+	directions := s.directions
+	if directions == nil {
+		directions = types.ClassicDirections
+	}
+
+	reader := s.mapReader
+	if reader == nil && (s.streaming || s.parallel) {
+		loaded, err := s.loadClassicWorld(ctx, directions)
 		if err != nil {
-			return city, err
+			return err
 		}
 
-		if city == nil {
-			city, err = s.world.AddCity(ctx, cityName)
-			if err != nil {
-				return city, err
-			}
+		if loaded {
+			return s.finishWorld(ctx)
+		}
+	}
+
+	if reader == nil {
+		var err error
+		reader, err = mapfile.NewMapReader(s.in, s.mapFormat)
+		if err != nil {
+			return err
 		}
-		return city, nil
 	}
 
-	scanner := bufio.NewScanner(s.in)
-	scanner.Split(bufio.ScanLines)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+	registry := directions.NewRegistry()
+	var records []cityRecord
+	for {
+		cityDefinition, err := reader.Next(ctx)
+		if err == io.EOF {
+			break
+		}
 
-		if len(line) == 0 {
-			continue
+		if err != nil {
+			return err
+		}
+
+		records = append(records, newCityRecord(cityDefinition, registry))
+	}
+
+	citiesFrom, err := registerCityRecords(ctx, s.world, records)
+	if err != nil {
+		return err
+	}
+
+	_, err = addRoadRecords(ctx, s.world, records, citiesFrom)
+	if err != nil {
+		return err
+	}
+
+	return s.finishWorld(ctx)
+}
+
+// loadClassicWorld loads a classic map with the StreamLoader or the ParallelLoader, returns false for the structured formats they can't load
+func (s *EngineImpl) loadClassicWorld(ctx context.Context, directions *types.DirectionProfile) (bool, error) {
+
+	format := s.mapFormat
+	if format == mapfile.FormatAuto || format == "" {
+		detected, in, err := mapfile.SniffFormat(s.in)
+		if err != nil {
+			return false, err
 		}
+		format, s.in = detected, in
+	}
+
+	if format != mapfile.FormatClassic {
+		return false, nil
+	}
+
+	opts := append([]LoaderOption{WithLoaderDirections(directions)}, s.loaderOptions...)
+	if s.streaming {
+		return true, NewStreamLoader(s.world, opts...).Load(ctx, s.in)
+	}
+
+	return true, NewParallelLoader(s.world, opts...).Load(ctx, s.in)
+}
+
+// finishWorld completes the loaded world
+func (s *EngineImpl) finishWorld(ctx context.Context) error {
+
+	if s.autoReverseRoads {
+		return s.reverseOneWayRoads(ctx)
+	}
+
+	return nil
+}
+
+// reverseOneWayRoads turns every one-way road into a two-way road when the way back is free
+func (s *EngineImpl) reverseOneWayRoads(ctx context.Context) error {
 
-		lineChunks := strings.Split(line, " ")
-		if len(lineChunks) == 0 {
-			return types.ERR_PARSE_CITY_DEFINITION
+	roads, err := s.world.GetRoads(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, road := range roads {
+		if road.TwoWay {
+			continue
 		}
 
-		cityFromName := lineChunks[0]
-		cityFrom, err := registerCity(cityFromName)
+		opposite, err := road.Direction.Opposite()
 		if err != nil {
 			return err
 		}
 
-		for _, lineChunk := range lineChunks[1:] {
-			linkChunks := strings.Split(strings.TrimSpace(lineChunk), "=")
-			if len(linkChunks) != 2 {
-				return types.ERR_PARSE_CITY_DEFINITION
-			}
+		cityBack, err := road.To.GetCityLink(opposite)
+		if err != nil {
+			return err
+		}
 
-			directionName := linkChunks[0]
-			cityToName := linkChunks[1]
-			cityTo, err := registerCity(cityToName)
-			if err != nil {
-				return err
-			}
+		if cityBack != nil {
+			log.Warnf("road %s kept one-way: %s already leads %s to %s", road, road.To.Name, opposite, cityBack.Name)
+			continue
+		}
 
-			var direction types.Direction
-			switch directionName {
-			case "north":
-				direction = types.North
-			case "east":
-				direction = types.East
-			case "south":
-				direction = types.South
-			case "west":
-				direction = types.West
-			default:
-				return types.ERR_PARSE_CITY_DEFINITION
-			}
-			err = s.world.AddLink(ctx, cityFrom, cityTo, direction)
-			if err != nil {
-				return err
-			}
-		
+		err = s.world.AddWeightedLink(ctx, road.To, road.From, opposite, road.Length)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -327,11 +729,23 @@ func (s *EngineImpl) moveAlienToCity(ctx context.Context, alien *types.Alien, ci
 	case alienAlreadyInCity == alien:
 		return destroyedCity, nil
 	case alienAlreadyInCity == nil:
+		event := Event{Type: EventLanded, Aliens: []int{alien.AlienID}, City: city.Name}
+		switch {
+		case alien.City != nil:
+			event.Type, event.From = EventMoved, alien.City.Name
+		case alien.IsInTransit():
+			event.Type, event.From, event.Road = EventMoved, alien.Road.Other(alien.Destination).Name, alien.Road.String()
+		}
+
 		err := s.world.MoveAlien(ctx, alien, city)
 		if err != nil {
 			return destroyedCity, err
 		}
+		s.emit(event)
 	default:
+		ctx, span := trace.Start(ctx, "Engine.fight", trace.Int("step", int(s.totalMoves)), trace.String("city", city.Name), trace.Ints("aliens", []int{alien.AlienID, alienAlreadyInCity.AlienID}))
+		defer span.End()
+
 		err = s.world.TrapAlien(ctx, alien)
 		if err != nil {
 			return destroyedCity, err
@@ -348,6 +762,8 @@ func (s *EngineImpl) moveAlienToCity(ctx context.Context, alien *types.Alien, ci
 		}
 
 		destroyedCity = true
+		s.emit(Event{Type: EventFought, Aliens: []int{alien.AlienID, alienAlreadyInCity.AlienID}, City: city.Name})
+		s.emit(Event{Type: EventDestroyed, City: city.Name})
 		_, err := fmt.Fprintf(s.out, "%s has been destroyed by %s and %s\n", city.Name, alien, alienAlreadyInCity)
 		if err != nil {
 			return destroyedCity, err
@@ -3,11 +3,16 @@ package engine
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 
+	"alien-invasion-cc/engine/mapfile"
 	"alien-invasion-cc/engine/types"
+	"alien-invasion-cc/trace"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -348,6 +353,89 @@ func Test_Engine_Run(t *testing.T) {
 		err := run(ctx, engineMock)
 		require.ErrorIs(t, err, error1)
 	})
+
+	t.Run("Case 6: Cancelled between steps", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		engineMock := &EngineMock{}
+		engineMock.On("LoadEngine", ctx).Return(nil).Once()
+		engineMock.On("HasNextMove", ctx).Return(true, nil).Times(totalSteps)
+		engineMock.On("DoNextMove", ctx).Return(nil).Times(totalSteps - 1)
+		engineMock.On("DoNextMove", ctx).Return(nil).Run(func(mock.Arguments) { cancel() }).Once()
+		engineMock.On("Finalize", ctx).Return(nil).Once()
+		defer engineMock.AssertExpectations(t)
+
+		err := run(ctx, engineMock)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Case 7: Deadline exceeded within a step", func(t *testing.T) {
+		ctx := context.Background()
+		deadlineExceeded := fmt.Errorf("%v: %w", types.ERR_CONTEXT_CANCELLED, context.DeadlineExceeded)
+
+		engineMock := &EngineMock{}
+		engineMock.On("LoadEngine", ctx).Return(nil).Once()
+		engineMock.On("HasNextMove", ctx).Return(true, nil).Once()
+		engineMock.On("DoNextMove", ctx).Return(deadlineExceeded).Once()
+		engineMock.On("Finalize", ctx).Return(nil).Once()
+		defer engineMock.AssertExpectations(t)
+
+		err := run(ctx, engineMock)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func Test_Engine_Run_Cancelled(t *testing.T) {
+	in, err := os.Open("../test_data/test_map2")
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// two aliens wander until cancelled within their third step, which both aliens still finish
+	out := &bytes.Buffer{}
+	movedInStep3 := 0
+	s := NewEngine(2, 1000, in, out, WithSeed(42), WithEventListener(func(e Event) {
+		if e.Step == 3 {
+			cancel()
+			if e.Type == EventMoved {
+				movedInStep3++
+			}
+		}
+	}))
+
+	err = s.Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, uint(3), s.totalMoves)
+	require.Equal(t, 2, movedInStep3)
+
+	// a cancelled step is neither taken nor counted
+	err = s.DoNextMove(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, uint(3), s.totalMoves)
+	require.Contains(t, out.String(), "Simulation Cancelled\n====================\nSteps Reached: 3/1000\nRemain Cities: 625\n")
+	require.NotContains(t, out.String(), "Simulation Finished")
+
+	// the world is left as the cancellation found it
+	result, err := s.Result(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint(3), result.Steps)
+	require.True(t, result.Finished)
+	require.Equal(t, TerminationCancelled, result.Termination)
+
+	// a cancelled load stops short
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = in.Seek(0, 0)
+	require.NoError(t, err)
+	s = NewEngine(1, 1000, in, out)
+	err = s.Run(cancelled)
+	require.ErrorIs(t, err, context.Canceled)
+	cities, err := s.World().GetCities(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, cities)
 }
 
 func Test_Engine_Finalize(t *testing.T) {
@@ -358,7 +446,9 @@ func Test_Engine_Finalize(t *testing.T) {
 		ctx := context.Background()
 
 		worldMock := &WorldMock{}
+		worldMock.On("GetUntrappedAliens", ctx).Return([]*types.Alien{}, nil).Once()
 		worldMock.On("GetAliveCities", ctx).Return([]*types.City{city1, city2}, nil).Once()
+		worldMock.On("GetAliensInTransit", ctx).Return([]*types.Alien{}, nil).Once()
 		defer worldMock.AssertExpectations(t)
 
 		out := &bytes.Buffer{}
@@ -374,7 +464,7 @@ func Test_Engine_Finalize(t *testing.T) {
 
 		err := s.Finalize(ctx)
 		require.NoError(t, err)
-		require.Equal(t, "\nCity1\nCity2\n", out.String())
+		require.Equal(t, "\n===================\nSimulation Finished\n===================\nTermination: every alien is trapped\nRemain Cities: 2\n\nCity1\nCity2\n", out.String())
 	})
 
 	t.Run("Case 2: Error", func(t *testing.T) {
@@ -383,6 +473,7 @@ func Test_Engine_Finalize(t *testing.T) {
 		error1 := fmt.Errorf("error 1")
 
 		worldMock := &WorldMock{}
+		worldMock.On("GetUntrappedAliens", ctx).Return([]*types.Alien{}, nil).Once()
 		worldMock.On("GetAliveCities", ctx).Return([]*types.City{nil}, error1).Once()
 		defer worldMock.AssertExpectations(t)
 
@@ -399,7 +490,7 @@ func Test_Engine_Finalize(t *testing.T) {
 
 		err := s.Finalize(ctx)
 		require.ErrorIs(t, err, error1)
-		require.Equal(t, "", out.String())
+		require.Equal(t, "\n===================\nSimulation Finished\n===================\nTermination: every alien is trapped\n", out.String())
 	})
 }
 
@@ -466,20 +557,20 @@ func Test_Engine_loadWorld(t *testing.T) {
 		worldMock.On("GetCity", ctx, "City7").Return(cityNil, nil).Once()
 		worldMock.On("AddCity", ctx, "City7").Return(city7, nil).Once()
 		// Links from City1
-		worldMock.On("AddLink", ctx, city1, city2, types.North).Return(nil).Once()
-		worldMock.On("AddLink", ctx, city1, city3, types.East).Return(nil).Once()
-		worldMock.On("AddLink", ctx, city1, city4, types.South).Return(nil).Once()
-		worldMock.On("AddLink", ctx, city1, city5, types.West).Return(nil).Once()
+		worldMock.On("AddWeightedLink", ctx, city1, city2, types.North, uint(1)).Return(nil).Once()
+		worldMock.On("AddWeightedLink", ctx, city1, city3, types.East, uint(1)).Return(nil).Once()
+		worldMock.On("AddWeightedLink", ctx, city1, city4, types.South, uint(1)).Return(nil).Once()
+		worldMock.On("AddWeightedLink", ctx, city1, city5, types.West, uint(1)).Return(nil).Once()
 		// Links from City2
-		worldMock.On("AddLink", ctx, city2, city1, types.East).Return(nil).Once()
-		worldMock.On("AddLink", ctx, city2, city4, types.South).Return(nil).Once()
+		worldMock.On("AddWeightedLink", ctx, city2, city1, types.East, uint(1)).Return(nil).Once()
+		worldMock.On("AddWeightedLink", ctx, city2, city4, types.South, uint(1)).Return(nil).Once()
 		// Links from City3
-		worldMock.On("AddLink", ctx, city3, city5, types.West).Return(nil).Once()
-		worldMock.On("AddLink", ctx, city3, city7, types.East).Return(nil).Once()
-		worldMock.On("AddLink", ctx, city3, city5, types.South).Return(nil).Once()
+		worldMock.On("AddWeightedLink", ctx, city3, city5, types.West, uint(1)).Return(nil).Once()
+		worldMock.On("AddWeightedLink", ctx, city3, city7, types.East, uint(1)).Return(nil).Once()
+		worldMock.On("AddWeightedLink", ctx, city3, city5, types.South, uint(1)).Return(nil).Once()
 		// Links from City4
-		worldMock.On("AddLink", ctx, city4, city3, types.East).Return(nil).Once()
-		worldMock.On("AddLink", ctx, city4, city5, types.North).Return(nil).Once()
+		worldMock.On("AddWeightedLink", ctx, city4, city3, types.East, uint(1)).Return(nil).Once()
+		worldMock.On("AddWeightedLink", ctx, city4, city5, types.North, uint(1)).Return(nil).Once()
 		defer worldMock.AssertExpectations(t)
 
 		input := `
@@ -539,10 +630,8 @@ City2 east=City1 south=City4
 	t.Run("Case 3: Incorrect format", func(t *testing.T) {
 		ctx := context.Background()
 
+		// a malformed line is rejected before any of its cities is registered
 		worldMock := &WorldMock{}
-		// City1
-		worldMock.On("GetCity", ctx, "City1").Return(cityNil, nil).Once()
-		worldMock.On("AddCity", ctx, "City1").Return(city1, nil).Once()
 		defer worldMock.AssertExpectations(t)
 
 		input := `
@@ -596,4 +685,516 @@ City2 east=City1 south=City4
 		err := s.loadWorld(ctx)
 		require.ErrorIs(t, err, error1)
 	})
-}
\ No newline at end of file
+}
+
+func Test_Engine_loadWorld_AutoReverseRoads(t *testing.T) {
+	input := `
+City1 north=City2 east=City3
+City2 south=City1
+City3
+City4 west=City1
+`
+
+	tests := []struct {
+		name             string
+		giveAutoReverse  bool
+		wantCity3        string
+		wantTwoWayRoads  int
+		wantOneWayRoads  int
+	}{
+		{"Auto reverse disabled", false, "City3", 1, 2},
+		{"Auto reverse enabled", true, "City3 west=City1", 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			s := NewEngine(0, 10, strings.NewReader(input), &bytes.Buffer{}, WithAutoReverseRoads(tt.giveAutoReverse))
+			err := s.loadWorld(ctx)
+			require.NoError(t, err)
+
+			city3, err := s.world.GetCity(ctx, "City3")
+			require.NoError(t, err)
+			require.Equal(t, tt.wantCity3, city3.String())
+
+			roads, err := s.world.GetRoads(ctx)
+			require.NoError(t, err)
+
+			twoWay, oneWay := 0, 0
+			for _, road := range roads {
+				if road.TwoWay {
+					twoWay++
+				} else {
+					oneWay++
+				}
+			}
+			require.Equal(t, tt.wantTwoWayRoads, twoWay)
+			require.Equal(t, tt.wantOneWayRoads, oneWay)
+		})
+	}
+}
+
+func Test_Engine_loadWorld_DirectionProfiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		profile   *types.DirectionProfile
+		input     string
+		wantError error
+	}{
+		{
+			name:    "Classic",
+			profile: types.ClassicDirections,
+			input:   "City1 north=City2 east=City3 south=City4 west=City5\nCity2 south=City1\n",
+		},
+		{
+			name:      "Classic rejects compass points",
+			profile:   types.ClassicDirections,
+			input:     "City1 northeast=City2\n",
+			wantError: types.ERR_PARSE_CITY_DEFINITION,
+		},
+		{
+			name:    "Compass",
+			profile: types.CompassDirections,
+			input:   "City1 north=City2 northeast=City3 southwest=City4 northwest=City5\nCity3 southwest=City1\n",
+		},
+		{
+			name:    "Layered",
+			profile: types.LayeredDirections,
+			input:   "City1 north=City3 up=City2\nCity2 southeast=City3 down=City1\n",
+		},
+		{
+			name:    "Free-form",
+			profile: types.FreeFormDirections,
+			input:   "City1 north=City3 bridge=City2 tunnel=City4\nCity2 bridge=City1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			s := NewEngine(0, 10, strings.NewReader(tt.input), &bytes.Buffer{}, WithDirectionProfile(tt.profile))
+			err := s.loadWorld(ctx)
+			require.ErrorIs(t, err, tt.wantError)
+			if err != nil {
+				return
+			}
+
+			output := func(s *EngineImpl) []string {
+				cities, err := s.world.GetAliveCities(ctx)
+				require.NoError(t, err)
+
+				lines := []string{}
+				for _, city := range cities {
+					lines = append(lines, city.String())
+				}
+				return lines
+			}
+
+			// the loaded world written back is parsed into the same world
+			written := output(s)
+			reloaded := NewEngine(0, 10, strings.NewReader(strings.Join(written, "\n")), &bytes.Buffer{}, WithDirectionProfile(tt.profile))
+			err = reloaded.loadWorld(ctx)
+			require.NoError(t, err)
+			require.ElementsMatch(t, written, output(reloaded))
+
+			for _, line := range strings.Split(strings.TrimSpace(tt.input), "\n") {
+				require.Contains(t, written, line)
+			}
+		})
+	}
+}
+
+func Test_Engine_loadWorld_FreeFormDirectionsPerMap(t *testing.T) {
+	ctx := context.Background()
+
+	// a map of one city per free-form direction, every road named after its city
+	freeFormMap := func(prefix string, roads int) string {
+		var lines strings.Builder
+		for i := 0; i < roads; i++ {
+			fmt.Fprintf(&lines, "%s%d %s%d=Hub\n", prefix, i, prefix, i)
+		}
+		return lines.String()
+	}
+
+	loaders := map[string][]Option{
+		"Serial":    nil,
+		"Streaming": {WithStreamingLoader()},
+		"Parallel":  {WithParallelLoader(WithChunkSize(4096))},
+	}
+
+	for name, opts := range loaders {
+		t.Run(name, func(t *testing.T) {
+			opts := append([]Option{WithDirectionProfile(types.FreeFormDirections)}, opts...)
+
+			// every map has room for its own free-form directions, none of them being held by the process
+			for _, prefix := range []string{"east", "west"} {
+				s := NewEngine(0, 10, strings.NewReader(freeFormMap(prefix, types.MaxFreeFormDirections)), &bytes.Buffer{}, opts...)
+				require.NoError(t, s.loadWorld(ctx))
+
+				city, err := s.world.GetCity(ctx, prefix+"0")
+				require.NoError(t, err)
+				require.Equal(t, []types.Direction{types.Direction(prefix + "0")}, city.Directions())
+			}
+
+			s := NewEngine(0, 10, strings.NewReader(freeFormMap("north", types.MaxFreeFormDirections+1)), &bytes.Buffer{}, opts...)
+			require.ErrorIs(t, s.loadWorld(ctx), types.ERR_TOO_MANY_DIRECTIONS)
+		})
+	}
+}
+
+func Test_Engine_DoNextMove_WeightedRoads(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Case 1: Alien travels a 3 steps road", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		s := NewEngine(0, 10, strings.NewReader("City1 east=City2:3\n"), out)
+		require.NoError(t, s.loadWorld(ctx))
+
+		city1, err := s.world.GetCity(ctx, "City1")
+		require.NoError(t, err)
+		city2, err := s.world.GetCity(ctx, "City2")
+		require.NoError(t, err)
+
+		alien1, err := s.world.AddAlien(ctx, 1)
+		require.NoError(t, err)
+		require.NoError(t, s.world.MoveAlien(ctx, alien1, city1))
+
+		for step := 1; step < 3; step++ {
+			require.NoError(t, s.DoNextMove(ctx))
+			require.True(t, alien1.IsInTransit())
+
+			for _, city := range []*types.City{city1, city2} {
+				alienFound, err := s.world.GetAlienAtCity(ctx, city)
+				require.NoError(t, err)
+				require.Nil(t, alienFound)
+			}
+		}
+
+		require.NoError(t, s.DoNextMove(ctx))
+		require.False(t, alien1.IsInTransit())
+		require.Equal(t, city2, alien1.City)
+	})
+
+	t.Run("Case 2: Destination destroyed while travelling", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		s := NewEngine(0, 10, strings.NewReader("City1 east=City2:2\n"), out)
+		require.NoError(t, s.loadWorld(ctx))
+
+		city1, err := s.world.GetCity(ctx, "City1")
+		require.NoError(t, err)
+		city2, err := s.world.GetCity(ctx, "City2")
+		require.NoError(t, err)
+
+		alien1, err := s.world.AddAlien(ctx, 1)
+		require.NoError(t, err)
+		require.NoError(t, s.world.MoveAlien(ctx, alien1, city1))
+
+		require.NoError(t, s.DoNextMove(ctx))
+		require.True(t, alien1.IsInTransit())
+
+		require.NoError(t, s.world.DestroyCity(ctx, city2))
+		require.NoError(t, s.DoNextMove(ctx))
+		require.True(t, alien1.IsTrapped)
+		require.Equal(t, "Alien #1 is stranded on the road to destroyed City2\n", out.String())
+	})
+
+	t.Run("Case 3: Aliens cross each other on a road", func(t *testing.T) {
+		for _, roadFights := range []bool{false, true} {
+			out := &bytes.Buffer{}
+			s := NewEngine(0, 10, strings.NewReader("City1 east=City2:3\nCity2 west=City1:3\n"), out, WithRoadFights(roadFights))
+			require.NoError(t, s.loadWorld(ctx))
+
+			city1, err := s.world.GetCity(ctx, "City1")
+			require.NoError(t, err)
+			city2, err := s.world.GetCity(ctx, "City2")
+			require.NoError(t, err)
+
+			alien1, err := s.world.AddAlien(ctx, 1)
+			require.NoError(t, err)
+			require.NoError(t, s.world.MoveAlien(ctx, alien1, city1))
+			alien2, err := s.world.AddAlien(ctx, 2)
+			require.NoError(t, err)
+			require.NoError(t, s.world.MoveAlien(ctx, alien2, city2))
+
+			require.NoError(t, s.DoNextMove(ctx))
+			require.Equal(t, roadFights, alien1.IsTrapped)
+			require.Equal(t, roadFights, alien2.IsTrapped)
+
+			aliveCities, err := s.world.GetAliveCities(ctx)
+			require.NoError(t, err)
+			require.Len(t, aliveCities, 2)
+
+			if roadFights {
+				require.Equal(t, "Alien #1 and Alien #2 fought on the road City1 <-> City2 (east, 3 steps)\n", out.String())
+			}
+		}
+	})
+}
+
+func Test_Engine_loadWorld_RoadLength(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantError error
+	}{
+		{"City1 north=City2:2\nCity2 south=City1:2\n", nil},
+		{"City1 north=City2:0\n", types.ERR_PARSE_CITY_DEFINITION},
+		{"City1 north=City2:x\n", types.ERR_PARSE_CITY_DEFINITION},
+		{"City1 north=City2:2:3\n", types.ERR_PARSE_CITY_DEFINITION},
+		{"City1 north=City2:2\nCity2 south=City1:3\n", types.ERR_ROAD_LENGTH_MISMATCH},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			s := NewEngine(0, 10, strings.NewReader(tt.input), &bytes.Buffer{})
+			err := s.loadWorld(context.Background())
+			require.ErrorIs(t, err, tt.wantError)
+		})
+	}
+}
+
+func Test_Engine_loadWorld_StructuredFormats(t *testing.T) {
+	for _, path := range []string{"../test_data/test_map.json", "../test_data/test_map.yaml"} {
+		t.Run(path, func(t *testing.T) {
+			ctx := context.Background()
+
+			in, err := os.Open(path)
+			require.NoError(t, err)
+			defer func() { _ = in.Close() }()
+
+			s := NewEngine(0, 10, in, &bytes.Buffer{})
+			err = s.loadWorld(ctx)
+			require.NoError(t, err)
+
+			paris, err := s.world.GetCity(ctx, "Paris")
+			require.NoError(t, err)
+			require.Equal(t, "Paris north=Brussels east=Berlin:3 west=Le Havre:2", paris.String())
+			require.Equal(t, map[string]string{"country": "France"}, paris.Attributes)
+			require.Equal(t, &types.Coordinates{X: 2.35, Y: 48.85}, paris.Coordinates)
+
+			leHavre, err := s.world.GetCity(ctx, "Le Havre")
+			require.NoError(t, err)
+			require.Equal(t, "Le Havre east=Paris:2", leHavre.String())
+
+			roads, err := s.world.GetRoads(ctx)
+			require.NoError(t, err)
+			require.Len(t, roads, 4)
+		})
+	}
+}
+
+func Test_Engine_MapWriter_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	write := func(s *EngineImpl, opts ...mapfile.WriterOption) string {
+		out := &bytes.Buffer{}
+		err := mapfile.NewMapWriter(out, opts...).WriteWorld(ctx, s.World())
+		require.NoError(t, err)
+		return out.String()
+	}
+
+	for _, path := range []string{"../test_data/test_map", "../test_data/test_map2"} {
+		t.Run(path, func(t *testing.T) {
+			in, err := os.Open(path)
+			require.NoError(t, err)
+			defer func() { _ = in.Close() }()
+
+			// parse -> write -> parse -> write is stable
+			s := NewEngine(0, 10, in, &bytes.Buffer{})
+			require.NoError(t, s.loadWorld(ctx))
+			written := write(s)
+
+			reloaded := NewEngine(0, 10, strings.NewReader(written), &bytes.Buffer{})
+			require.NoError(t, reloaded.loadWorld(ctx))
+			require.Equal(t, written, write(reloaded))
+
+			// the post-invasion world is fed back into another run
+			invaded := NewEngine(10, 100, strings.NewReader(written), &bytes.Buffer{})
+			require.NoError(t, invaded.Run(ctx))
+			remaining := write(invaded, mapfile.WithDestroyedCities(true))
+
+			next := NewEngine(0, 10, strings.NewReader(remaining), &bytes.Buffer{})
+			require.NoError(t, next.loadWorld(ctx))
+			require.Equal(t, write(invaded), write(next))
+			require.Equal(t, write(invaded, mapfile.WithSortedCities(true)), write(next, mapfile.WithSortedCities(true)))
+		})
+	}
+}
+
+// loadErrors lists the typed errors loading a map may end with
+var loadErrors = []error{
+	types.ERR_PARSE_CITY_DEFINITION,
+	types.ERR_EMPTY_CITY_NAME,
+	types.ERR_LINK_SAME_CITY,
+	types.ERR_ALREADY_EXISTS_LINK,
+	types.ERR_INVALID_ROAD_LENGTH,
+	types.ERR_ROAD_LENGTH_MISMATCH,
+	types.ERR_UNKNOWN_DIRECTION,
+	types.ERR_LINE_TOO_LONG,
+}
+
+func FuzzEngine_loadWorld(f *testing.F) {
+	entries, err := os.ReadDir("../test_data")
+	require.NoError(f, err)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		content, err := os.ReadFile("../test_data/" + entry.Name())
+		require.NoError(f, err)
+		f.Add(content, uint8(0))
+	}
+
+	f.Add([]byte("Foo north=Bar:3 up=Baz\nBar south=Foo:3\n"), uint8(2))
+	f.Add([]byte("Foo bridge=Bar tunnel=Baz:2\n"), uint8(3))
+	f.Add([]byte(`{"cities":[{"name":"Foo","roads":[{"direction":"north","to":"Bar"}]}]}`), uint8(0))
+
+	f.Fuzz(func(t *testing.T, content []byte, options uint8) {
+		ctx := context.Background()
+
+		// the options pick the direction profile and the loader
+		opts := []Option{WithDirectionProfile(types.DirectionProfiles[int(options)%len(types.DirectionProfiles)])}
+		switch options / 4 % 3 {
+		case 1:
+			opts = append(opts, WithStreamingLoader(WithMaxLineSize(1024)))
+		case 2:
+			opts = append(opts, WithParallelLoader(WithChunkSize(16), WithWorkers(2)))
+		}
+
+		s := NewEngine(0, 10, bytes.NewReader(content), &bytes.Buffer{}, opts...)
+		err := s.loadWorld(ctx)
+		if err != nil {
+			for _, loadError := range loadErrors {
+				if errors.Is(err, loadError) {
+					return
+				}
+			}
+			t.Fatalf("untyped error loading the map: %v", err)
+		}
+
+		world, ok := s.world.(*WorldImpl)
+		require.True(t, ok)
+		require.NoError(t, checkWorldInvariants(world))
+	})
+}
+
+func Test_Engine_Tracing(t *testing.T) {
+	in, err := os.Open("../test_data/test_map2")
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	exporter := trace.NewInMemoryExporter()
+	ctx := trace.ContextWithTracer(context.Background(), trace.NewTracer(exporter))
+
+	s := NewEngine(40, 100, in, &bytes.Buffer{}, WithSeed(42))
+	require.NoError(t, s.Run(ctx))
+
+	spans := exporter.Spans()
+	byID := map[string]trace.SpanData{}
+	byName := map[string][]trace.SpanData{}
+	for _, span := range spans {
+		byID[span.SpanID] = span
+		byName[span.Name] = append(byName[span.Name], span)
+	}
+
+	// the run is the root span, ended last
+	root := spans[len(spans)-1]
+	require.Equal(t, "Engine.Run", root.Name)
+	require.Empty(t, root.ParentID)
+	require.Equal(t, map[string]interface{}{"aliens": 40, "max_moves": 100, "steps": int(s.totalMoves)}, root.Attributes)
+	for _, span := range spans {
+		require.Equal(t, root.TraceID, span.TraceID)
+	}
+
+	require.Len(t, byName["Engine.loadWorld"], 1)
+	require.Equal(t, root.SpanID, byName["Engine.loadWorld"][0].ParentID)
+
+	require.Len(t, byName["Engine.DoNextMove"], int(s.totalMoves))
+	for i, step := range byName["Engine.DoNextMove"] {
+		require.Equal(t, root.SpanID, step.ParentID)
+		require.Equal(t, i+1, step.Attributes["step"])
+	}
+
+	// fights happen within a step, or when landing, and destroy the city fought in
+	require.NotEmpty(t, byName["Engine.fight"])
+	for _, fight := range byName["Engine.fight"] {
+		if fight.Attributes["step"] == 0 {
+			require.Equal(t, root.SpanID, fight.ParentID)
+		} else {
+			step := byID[fight.ParentID]
+			require.Equal(t, "Engine.DoNextMove", step.Name)
+			require.Equal(t, step.Attributes["step"], fight.Attributes["step"])
+		}
+		require.Len(t, fight.Attributes["aliens"], 2)
+		require.NotEmpty(t, fight.Attributes["city"])
+	}
+
+	require.Len(t, byName["World.DestroyCity"], len(byName["Engine.fight"]))
+	for _, destroyed := range byName["World.DestroyCity"] {
+		fight := byID[destroyed.ParentID]
+		require.Equal(t, "Engine.fight", fight.Name)
+		require.Equal(t, fight.Attributes["city"], destroyed.Attributes["city"])
+	}
+
+	// without a tracer nothing is traced
+	exporter.Reset()
+	_, err = in.Seek(0, 0)
+	require.NoError(t, err)
+	require.NoError(t, NewEngine(40, 100, in, &bytes.Buffer{}, WithSeed(42)).Run(context.Background()))
+	require.Empty(t, exporter.Spans())
+}
+
+func Test_Engine_LoadEngine_Placement(t *testing.T) {
+	ctx := context.Background()
+
+	in, err := os.ReadFile("../test_data/test_map")
+	require.NoError(t, err)
+
+	// aliens placed in the same city fight as they land
+	s := NewEngine(3, 10, bytes.NewReader(in), &bytes.Buffer{}, WithSeed(1), WithPlacement(map[int]string{1: "Paris", 2: "Paris", 3: "Athens"}))
+	require.NoError(t, s.LoadEngine(ctx))
+
+	paris, err := s.world.GetCity(ctx, "Paris")
+	require.NoError(t, err)
+	require.Nil(t, paris)
+
+	aliens, err := s.world.GetUntrappedAliens(ctx)
+	require.NoError(t, err)
+	require.Len(t, aliens, 1)
+	require.Equal(t, 3, aliens[0].AlienID)
+	require.Equal(t, "Athens", aliens[0].City.Name)
+
+	s = NewEngine(2, 10, bytes.NewReader(in), &bytes.Buffer{}, WithPlacement(map[int]string{2: "Atlantis"}))
+	err = s.LoadEngine(ctx)
+	require.ErrorIs(t, err, types.ERR_UNKNOWN_CITY)
+	require.EqualError(t, err, "city is unknown: alien #2 can't land in Atlantis")
+}
+
+func Test_Engine_LoadEngine_MapReader(t *testing.T) {
+	ctx := context.Background()
+
+	west := mapfile.NewClassicReader(strings.NewReader("Paris east=Berlin\n"))
+	east := mapfile.NewClassicReader(strings.NewReader("Berlin west=Paris east=Warsaw\n"))
+
+	// the map reader takes precedence over the input and its loaders
+	s := NewEngine(0, 10, strings.NewReader("Atlantis"), &bytes.Buffer{},
+		WithMapReader(mapfile.NewMergedReader(mapfile.Source{Name: "west", Reader: west}, mapfile.Source{Name: "east", Reader: east})),
+		WithStreamingLoader(),
+	)
+	require.NoError(t, s.LoadEngine(ctx))
+
+	cities, err := s.world.GetCities(ctx)
+	require.NoError(t, err)
+	require.Len(t, cities, 3)
+	require.Equal(t, "Berlin", cities[0].Links[types.East].Name)
+
+	duplicate := mapfile.NewMergedReader(
+		mapfile.Source{Name: "west", Reader: mapfile.NewClassicReader(strings.NewReader("Paris east=Berlin\n"))},
+		mapfile.Source{Name: "east", Reader: mapfile.NewClassicReader(strings.NewReader("Paris west=Brest\n"))},
+	)
+	s = NewEngine(0, 10, nil, &bytes.Buffer{}, WithMapReader(duplicate))
+	require.ErrorIs(t, s.LoadEngine(ctx), types.ERR_DUPLICATE_CITY)
+}
@@ -0,0 +1,55 @@
+package engine
+
+// EventType names what happened in an Event
+type EventType string
+
+const (
+	// EventLanded an alien landed in its first city
+	EventLanded EventType = "landed"
+	// EventMoved an alien moved into a city
+	EventMoved EventType = "moved"
+	// EventDeparted an alien left its city on a road longer than one step
+	EventDeparted EventType = "departed"
+	// EventFought two aliens fought in a city
+	EventFought EventType = "fought"
+	// EventDestroyed a city was destroyed
+	EventDestroyed EventType = "destroyed"
+	// EventStranded an alien reached the end of a road leading to a destroyed city
+	EventStranded EventType = "stranded"
+	// EventRoadFight two aliens crossing each other on a road fought
+	EventRoadFight EventType = "road_fight"
+	// EventFinished the simulation is over
+	EventFinished EventType = "finished"
+)
+
+// Event Type definition, something which happened during a simulation
+type Event struct {
+	// Step the event happened at, 0 while landing the aliens
+	Step uint `json:"step"`
+	// Type of the event
+	Type EventType `json:"type"`
+	// IDs of the aliens involved
+	Aliens []int `json:"aliens,omitempty"`
+	// City the event happened in
+	City string `json:"city,omitempty"`
+	// City an alien came from
+	From string `json:"from,omitempty"`
+	// Road the event happened on
+	Road string `json:"road,omitempty"`
+	// Reason the simulation ended for, on EventFinished
+	Reason TerminationReason `json:"reason,omitempty"`
+}
+
+// emit hands an event to the metrics and the event listener, if any
+func (s *EngineImpl) emit(event Event) {
+	if s.metrics != nil {
+		s.metrics.observe(event)
+	}
+
+	if s.eventListener == nil {
+		return
+	}
+
+	event.Step = s.totalMoves
+	s.eventListener(event)
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// seededRun runs a seeded simulation of test_map2, returns its output and events
+func seededRun(t *testing.T, seed int64) (string, []Event) {
+	in, err := os.Open("../test_data/test_map2")
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	var events []Event
+	out := &bytes.Buffer{}
+	s := NewEngine(40, 100, in, out, WithSeed(seed), WithEventListener(func(e Event) { events = append(events, e) }))
+	require.NoError(t, s.Run(context.Background()))
+	return out.String(), events
+}
+
+func Test_Engine_Events(t *testing.T) {
+	out, events := seededRun(t, 42)
+
+	// the same seed gives the same invasion
+	sameOut, sameEvents := seededRun(t, 42)
+	require.Equal(t, out, sameOut)
+	require.Equal(t, events, sameEvents)
+
+	otherOut, _ := seededRun(t, 7)
+	require.NotEqual(t, out, otherOut)
+
+	landed := map[int]bool{}
+	fights := 0
+	for i, event := range events {
+		switch event.Type {
+		case EventLanded:
+			require.Zero(t, event.Step)
+			require.Len(t, event.Aliens, 1)
+			landed[event.Aliens[0]] = true
+		case EventMoved:
+			require.NotZero(t, event.Step)
+			require.NotEmpty(t, event.From)
+			require.NotEmpty(t, event.City)
+		case EventFought:
+			fights++
+			require.Len(t, event.Aliens, 2)
+			require.Equal(t, Event{Step: event.Step, Type: EventDestroyed, City: event.City}, events[i+1])
+			require.Contains(t, out, event.City+" has been destroyed")
+		}
+	}
+
+	require.NotZero(t, fights)
+	require.Equal(t, EventFinished, events[len(events)-1].Type)
+
+	// aliens landing on an occupied city fight instead
+	for alienID := 1; alienID <= 40; alienID++ {
+		if !landed[alienID] {
+			require.Contains(t, out, fmt.Sprintf("by Alien #%d and", alienID))
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"alien-invasion-cc/engine/types"
+)
+
+// Fragmentation Type definition, how the destruction split the map, roads being followed both ways
+type Fragmentation struct {
+	// Number of connected components before the invasion
+	ComponentsBefore int `json:"components_before"`
+	// Number of connected components of the cities left standing
+	ComponentsAfter int `json:"components_after"`
+	// Number of cities of the largest component before the invasion
+	LargestBefore int `json:"largest_before"`
+	// Number of cities of the largest component left standing
+	LargestAfter int `json:"largest_after"`
+	// Number of pairs of cities left standing which could reach each other before the invasion and no longer can
+	DisconnectedPairs int64 `json:"disconnected_pairs"`
+	// Cities left standing with no road to or from another city any more, in map order
+	IsolatedCities []string `json:"isolated_cities"`
+}
+
+// connectivity is the connected components of a map at some point
+type connectivity struct {
+	// component of every city
+	components map[*types.City]int
+
+	// number of cities of every component
+	sizes map[int]int
+}
+
+// newConnectivity computes the connected components of cities
+func newConnectivity(cities []*types.City) *connectivity {
+	c := &connectivity{
+		components: connectedComponents(cities),
+		sizes:      make(map[int]int),
+	}
+
+	for _, component := range c.components {
+		c.sizes[component]++
+	}
+
+	return c
+}
+
+// largest retrieves the number of cities of the largest component
+func (c *connectivity) largest() int {
+	largest := 0
+	for _, size := range c.sizes {
+		if size > largest {
+			largest = size
+		}
+	}
+	return largest
+}
+
+// snapshotConnectivity records the connected components of the map loaded, before any alien lands
+func (s *EngineImpl) snapshotConnectivity(ctx context.Context) error {
+
+	cities, err := s.world.GetAliveCities(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.initialConnectivity = newConnectivity(cities)
+	return nil
+}
+
+// Fragmentation computes how the destruction split the map so far, nil unless reported
+func (s *EngineImpl) Fragmentation(ctx context.Context) (*Fragmentation, error) {
+
+	if s.initialConnectivity == nil {
+		return nil, nil
+	}
+
+	cities, err := s.world.GetAliveCities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	before, after := s.initialConnectivity, newConnectivity(cities)
+	fragmentation := &Fragmentation{
+		ComponentsBefore: len(before.sizes),
+		ComponentsAfter:  len(after.sizes),
+		LargestBefore:    before.largest(),
+		LargestAfter:     after.largest(),
+		IsolatedCities:   []string{},
+	}
+
+	// components only ever split: the cities left of a former component are spread over the current ones
+	survivors := make(map[int]int64)
+	for _, city := range cities {
+		survivors[before.components[city]]++
+
+		if after.sizes[after.components[city]] == 1 && before.sizes[before.components[city]] > 1 {
+			fragmentation.IsolatedCities = append(fragmentation.IsolatedCities, city.Name)
+		}
+	}
+
+	for _, count := range survivors {
+		fragmentation.DisconnectedPairs += pairs(count)
+	}
+
+	for _, size := range after.sizes {
+		fragmentation.DisconnectedPairs -= pairs(int64(size))
+	}
+
+	return fragmentation, nil
+}
+
+// pairs counts the pairs among a number of cities
+func pairs(count int64) int64 {
+	return count * (count - 1) / 2
+}
+
+// finalizeFragmentation outputs how the destruction split the map
+func (s *EngineImpl) finalizeFragmentation(ctx context.Context) error {
+
+	fragmentation, err := s.Fragmentation(ctx)
+	if err != nil || fragmentation == nil {
+		return err
+	}
+
+	fmt.Fprintf(s.out, "\nComponents: %d before, %d after\n", fragmentation.ComponentsBefore, fragmentation.ComponentsAfter)
+	fmt.Fprintf(s.out, "Largest Component: %d before, %d after\n", fragmentation.LargestBefore, fragmentation.LargestAfter)
+	fmt.Fprintf(s.out, "Disconnected Pairs: %d\n", fragmentation.DisconnectedPairs)
+	fmt.Fprintf(s.out, "Isolated Cities: %d\n", len(fragmentation.IsolatedCities))
+
+	if len(fragmentation.IsolatedCities) == 0 {
+		return nil
+	}
+
+	_, err = fmt.Fprintln(s.out, "")
+	if err != nil {
+		return err
+	}
+
+	for _, city := range fragmentation.IsolatedCities {
+		_, err = fmt.Fprintln(s.out, city)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
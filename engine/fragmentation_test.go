@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Engine_Fragmentation(t *testing.T) {
+	ctx := context.Background()
+
+	// a path A - B - C - D - E, and F on its own
+	out := &bytes.Buffer{}
+	s := NewEngine(0, 10, strings.NewReader("A east=B\nB east=C\nC east=D\nD east=E\nE\nF\n"), out, WithAutoReverseRoads(true), WithFragmentationReport(true))
+	require.NoError(t, s.LoadEngine(ctx))
+
+	fragmentation, err := s.Fragmentation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &Fragmentation{
+		ComponentsBefore: 2,
+		ComponentsAfter:  2,
+		LargestBefore:    5,
+		LargestAfter:     5,
+		IsolatedCities:   []string{},
+	}, fragmentation)
+
+	destroy := func(name string) {
+		city, err := s.world.GetCity(ctx, name)
+		require.NoError(t, err)
+		require.NoError(t, s.world.DestroyCity(ctx, city))
+	}
+
+	// A - B and D - E can't reach each other any more
+	destroy("C")
+	fragmentation, err = s.Fragmentation(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &Fragmentation{
+		ComponentsBefore:  2,
+		ComponentsAfter:   3,
+		LargestBefore:     5,
+		LargestAfter:      2,
+		DisconnectedPairs: 4,
+		IsolatedCities:    []string{},
+	}, fragmentation)
+
+	// A is left on its own, unlike F which always was, and cut from D and E
+	destroy("B")
+	require.NoError(t, s.Finalize(ctx))
+	require.Contains(t, out.String(), "\nComponents: 2 before, 3 after\nLargest Component: 5 before, 2 after\nDisconnected Pairs: 2\nIsolated Cities: 1\n\nA\n")
+
+	result, err := s.Result(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &Fragmentation{
+		ComponentsBefore:  2,
+		ComponentsAfter:   3,
+		LargestBefore:     5,
+		LargestAfter:      2,
+		DisconnectedPairs: 2,
+		IsolatedCities:    []string{"A"},
+	}, result.Fragmentation)
+}
+
+func Test_Engine_Fragmentation_Run(t *testing.T) {
+	ctx := context.Background()
+
+	in, err := os.Open("../test_data/test_map2")
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	out := &bytes.Buffer{}
+	s := NewEngine(200, 100, in, out, WithSeed(7), WithFragmentationReport(true))
+	require.NoError(t, s.Run(ctx))
+
+	result, err := s.Result(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, result.Fragmentation)
+	require.Equal(t, 1, result.Fragmentation.ComponentsBefore)
+	require.Equal(t, 625, result.Fragmentation.LargestBefore)
+	require.GreaterOrEqual(t, result.RemainingCities, result.Fragmentation.LargestAfter)
+	require.Contains(t, out.String(), "Components: 1 before, ")
+
+	// not reported unless asked for
+	_, err = in.Seek(0, 0)
+	require.NoError(t, err)
+	s = NewEngine(200, 100, in, &bytes.Buffer{}, WithSeed(7))
+	require.NoError(t, s.Run(ctx))
+	result, err = s.Result(ctx)
+	require.NoError(t, err)
+	require.Nil(t, result.Fragmentation)
+}
@@ -12,12 +12,22 @@ type World interface {
 	GetCity(ctx context.Context, cityName string) (*types.City, error)
 	// GetAliveCities retrieves the list of non destroyed cities
 	GetAliveCities(ctx context.Context) ([]*types.City, error)
+	// GetCities retrieves the list of all cities, destroyed ones included
+	GetCities(ctx context.Context) ([]*types.City, error)
 	// AddCity adds a city
 	AddCity(ctx context.Context, cityName string) (*types.City, error)
 	// DestroyCity destroys a city
 	DestroyCity(ctx context.Context, city *types.City) error
 	// AddLink adds a link from a city to another city given a direction
 	AddLink(ctx context.Context, cityFrom, cityTo *types.City, direction types.Direction) error
+	// AddWeightedLink adds a link from a city to another city given a direction, travelled in a number of steps
+	AddWeightedLink(ctx context.Context, cityFrom, cityTo *types.City, direction types.Direction, length uint) error
+	// AddRoad adds a one-way or two-way road between two cities
+	AddRoad(ctx context.Context, road *types.Road) error
+	// GetRoads retrieves the list of roads between non destroyed cities
+	GetRoads(ctx context.Context) ([]*types.Road, error)
+	// GetCityRoads retrieves the list of roads leading into or out of a city
+	GetCityRoads(ctx context.Context, city *types.City) ([]*types.Road, error)
 	// GetAlien retrieves an alien
 	GetAlien(ctx context.Context, alienID int) (*types.Alien, error)
 	// AddAlien adds an alien
@@ -32,6 +42,12 @@ type World interface {
 	GetAlienAtCity(ctx context.Context, city *types.City) (*types.Alien, error)
 	// GetUntrappedAliens retrieves the list of untrapped aliens
 	GetUntrappedAliens(ctx context.Context) ([]*types.Alien, error)
+	// SendAlien sends an alien from its city on the road leading in a given direction
+	SendAlien(ctx context.Context, alien *types.Alien, direction types.Direction) error
+	// TravelAlien moves an alien one step further on its road, returns true once it reached its destination
+	TravelAlien(ctx context.Context, alien *types.Alien) (bool, error)
+	// GetAliensInTransit retrieves the list of untrapped aliens travelling on a road
+	GetAliensInTransit(ctx context.Context) ([]*types.Alien, error)
 }
 
 // Simulator is an alien invasion simulator interface
@@ -40,6 +56,8 @@ type Engine interface {
 	LoadEngine(ctx context.Context) error
 	// HasNextMove computes if a next step of the simulation exists
 	HasNextMove(ctx context.Context) (bool, error)
+	// TerminationReason computes why the simulation is over, TerminationNone while a next step exists
+	TerminationReason(ctx context.Context) (TerminationReason, error)
 	// DoNextMove simulates the next step of the simulation
 	DoNextMove(ctx context.Context) error
 	// Run simulates an alien invasion
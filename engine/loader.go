@@ -0,0 +1,445 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"runtime"
+
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+)
+
+// LoadProgress Type definition, a snapshot of a map being loaded
+type LoadProgress struct {
+	// Pass being run: 1 registers the defined cities, 2 adds the roads
+	Pass int
+	// Lines read so far in the pass
+	Lines int64
+	// Bytes read so far in the pass
+	Bytes int64
+	// Cities registered so far
+	Cities int
+	// Road definitions read so far, a two-way road listed on both sides counts twice
+	Roads int64
+	// Flag whether the pass is over
+	Done bool
+}
+
+// DefaultProgressInterval is the number of lines between two progress reports
+const DefaultProgressInterval = 100000
+
+// loaderConfig holds the settings shared by the map loaders
+type loaderConfig struct {
+	directions *types.DirectionProfile
+
+	bufferSize int
+
+	maxLineSize int
+
+	progress func(LoadProgress)
+
+	progressInterval int64
+
+	workers int
+
+	chunkSize int
+}
+
+// newLoaderConfig applies the options over the defaults
+func newLoaderConfig(opts []LoaderOption) loaderConfig {
+	c := loaderConfig{
+		directions:       types.ClassicDirections,
+		bufferSize:       mapfile.DefaultBufferSize,
+		maxLineSize:      mapfile.DefaultMaxLineSize,
+		progressInterval: DefaultProgressInterval,
+		workers:          runtime.GOMAXPROCS(0),
+		chunkSize:        DefaultChunkSize,
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// LoaderOption configures a StreamLoader or a ParallelLoader
+type LoaderOption func(*loaderConfig)
+
+// WithBufferSize sets the initial size of the buffer lines are read into
+func WithBufferSize(size int) LoaderOption {
+	return func(c *loaderConfig) {
+		c.bufferSize = size
+	}
+}
+
+// WithMaxLineSize sets the length beyond which a line is rejected
+func WithMaxLineSize(size int) LoaderOption {
+	return func(c *loaderConfig) {
+		c.maxLineSize = size
+	}
+}
+
+// WithProgress reports the loading progress every given number of lines, and at the end of each pass
+func WithProgress(report func(LoadProgress), interval int64) LoaderOption {
+	return func(c *loaderConfig) {
+		c.progress = report
+		if interval > 0 {
+			c.progressInterval = interval
+		}
+	}
+}
+
+// WithLoaderDirections sets the directions the map may use
+func WithLoaderDirections(directions *types.DirectionProfile) LoaderOption {
+	return func(c *loaderConfig) {
+		if directions != nil {
+			c.directions = directions
+		}
+	}
+}
+
+// WithWorkers sets the number of goroutines a ParallelLoader parses the map with, all the CPUs by default
+func WithWorkers(workers int) LoaderOption {
+	return func(c *loaderConfig) {
+		if workers > 0 {
+			c.workers = workers
+		}
+	}
+}
+
+// WithChunkSize sets the size of the chunks a ParallelLoader splits the map into
+func WithChunkSize(size int) LoaderOption {
+	return func(c *loaderConfig) {
+		if size > 0 {
+			c.chunkSize = size
+		}
+	}
+}
+
+// StreamLoader loads classic maps of any size into a world, line by line
+type StreamLoader struct {
+	loaderConfig
+
+	world World
+
+	cities int
+
+	roads int64
+}
+
+// Generate New StreamLoader
+func NewStreamLoader(world World, opts ...LoaderOption) *StreamLoader {
+	return &StreamLoader{
+		loaderConfig: newLoaderConfig(opts),
+		world:        world,
+	}
+}
+
+// Load loads a classic map in two passes: the defined cities first, in file order, then the roads between them
+func (l *StreamLoader) Load(ctx context.Context, in io.Reader) error {
+	source, cleanup, err := rewindable(in)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	start, err := source.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	err = l.registerCities(ctx, source)
+	if err != nil {
+		return err
+	}
+
+	_, err = source.Seek(start, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	return l.addRoads(ctx, source, l.directions.NewRegistry())
+}
+
+// registerCities registers the city defined by every line
+func (l *StreamLoader) registerCities(ctx context.Context, in io.Reader) error {
+	scanner := mapfile.NewLineScanner(in, l.bufferSize, l.maxLineSize)
+	var lines int64
+	for scanner.Scan() {
+		if err := contextError(ctx); err != nil {
+			return err
+		}
+
+		lines++
+		line := scanner.Text()
+		cityName := line
+		for i := 0; i < len(line); i++ {
+			if line[i] == ' ' {
+				cityName = line[:i]
+				break
+			}
+		}
+
+		_, err := l.intern(ctx, cityName)
+		if err != nil {
+			return err
+		}
+
+		l.report(1, lines, scanner.Offset(), false)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	l.report(1, lines, scanner.Offset(), true)
+	return nil
+}
+
+// addRoads adds the roads defined by every line
+func (l *StreamLoader) addRoads(ctx context.Context, in io.Reader, directions *types.DirectionRegistry) error {
+	scanner := mapfile.NewLineScanner(in, l.bufferSize, l.maxLineSize)
+	var lines int64
+	for scanner.Scan() {
+		if err := contextError(ctx); err != nil {
+			return err
+		}
+
+		lines++
+		cityDefinition, err := mapfile.ParseClassicLine(scanner.Text())
+		if err != nil {
+			return err
+		}
+
+		cityFrom, err := l.intern(ctx, cityDefinition.Name)
+		if err != nil {
+			return err
+		}
+
+		for _, road := range cityDefinition.Roads {
+			direction, err := directions.Parse(road.Direction)
+			if err != nil {
+				return directionError(err)
+			}
+
+			cityTo, err := l.intern(ctx, road.To)
+			if err != nil {
+				return err
+			}
+
+			length := road.Length
+			if length == 0 {
+				length = 1
+			}
+
+			err = l.world.AddWeightedLink(ctx, cityFrom, cityTo, direction, length)
+			if err != nil {
+				return err
+			}
+			l.roads++
+		}
+
+		l.report(2, lines, scanner.Offset(), false)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	l.report(2, lines, scanner.Offset(), true)
+	return nil
+}
+
+// intern retrieves a city by name, registering it the first time it is met; the world holds the only
+// copy of every name, the roads leading to a city sharing it
+func (l *StreamLoader) intern(ctx context.Context, cityName string) (*types.City, error) {
+	city, err := l.world.GetCity(ctx, cityName)
+	if err != nil {
+		return nil, err
+	}
+
+	if city != nil {
+		return city, nil
+	}
+
+	// the name is copied, a name cut from a line keeping the whole line in memory
+	city, err = l.world.AddCity(ctx, string([]byte(cityName)))
+	if err != nil {
+		return nil, err
+	}
+
+	l.cities++
+	return city, nil
+}
+
+// report reports the progress every interval lines, and at the end of a pass
+func (l *StreamLoader) report(pass int, lines, bytes int64, done bool) {
+	if l.progress == nil {
+		return
+	}
+
+	if !done && lines%l.progressInterval != 0 {
+		return
+	}
+
+	l.progress(LoadProgress{
+		Pass:   pass,
+		Lines:  lines,
+		Bytes:  bytes,
+		Cities: l.cities,
+		Roads:  l.roads,
+		Done:   done,
+	})
+}
+
+// rewindable retrieves a reader which can be read twice, spooling the input to a temporary file if needed
+func rewindable(in io.Reader) (io.ReadSeeker, func(), error) {
+	if seeker, ok := in.(io.ReadSeeker); ok {
+		return seeker, func() {}, nil
+	}
+
+	spool, err := os.CreateTemp("", "alien-invasion-map-*")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		_ = spool.Close()
+		_ = os.Remove(spool.Name())
+	}
+
+	_, err = io.Copy(spool, in)
+	if err == nil {
+		_, err = spool.Seek(0, io.SeekStart)
+	}
+
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	return spool, cleanup, nil
+}
+
+// cityRecord is a parsed city definition with the directions of its roads resolved
+type cityRecord struct {
+	definition *mapfile.CityDefinition
+
+	directions []types.Direction
+
+	// index of the first road with an unknown direction, -1 if there is none
+	invalidRoad int
+
+	// error parsing the direction of the invalid road
+	err error
+}
+
+// newCityRecord resolves the directions of the roads of a city definition
+func newCityRecord(definition *mapfile.CityDefinition, directions *types.DirectionRegistry) cityRecord {
+	record := cityRecord{
+		definition:  definition,
+		directions:  make([]types.Direction, 0, len(definition.Roads)),
+		invalidRoad: -1,
+	}
+
+	for i, road := range definition.Roads {
+		direction, err := directions.Parse(road.Direction)
+		if err != nil {
+			record.invalidRoad = i
+			record.err = err
+			break
+		}
+		record.directions = append(record.directions, direction)
+	}
+
+	return record
+}
+
+// directionError is the error of a road whose direction can't be parsed, a map using too many free-form
+// directions being told apart from a malformed one
+func directionError(err error) error {
+	if errors.Is(err, types.ERR_TOO_MANY_DIRECTIONS) {
+		return err
+	}
+	return types.ERR_PARSE_CITY_DEFINITION
+}
+
+// registerCity retrieves a city by name, adding it to the world the first time it is met
+func registerCity(ctx context.Context, world World, cityName string) (*types.City, error) {
+	city, err := world.GetCity(ctx, cityName)
+	if err != nil {
+		return city, err
+	}
+
+	if city == nil {
+		city, err = world.AddCity(ctx, cityName)
+		if err != nil {
+			return city, err
+		}
+	}
+	return city, nil
+}
+
+// registerCityRecords registers the defined cities in the order they are defined, before any road refers to them
+func registerCityRecords(ctx context.Context, world World, records []cityRecord) ([]*types.City, error) {
+	citiesFrom := make([]*types.City, len(records))
+	for i, record := range records {
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+
+		cityFrom, err := registerCity(ctx, world, record.definition.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(record.definition.Attributes) > 0 {
+			cityFrom.Attributes = record.definition.Attributes
+		}
+
+		if record.definition.Coordinates != nil {
+			cityFrom.Coordinates = record.definition.Coordinates
+		}
+
+		citiesFrom[i] = cityFrom
+	}
+
+	return citiesFrom, nil
+}
+
+// addRoadRecords adds the roads of the defined cities, returns the number of roads added
+func addRoadRecords(ctx context.Context, world World, records []cityRecord, citiesFrom []*types.City) (int64, error) {
+	var roads int64
+	for i, record := range records {
+		if err := contextError(ctx); err != nil {
+			return roads, err
+		}
+
+		for j, road := range record.definition.Roads {
+			if j == record.invalidRoad {
+				return roads, directionError(record.err)
+			}
+
+			cityTo, err := registerCity(ctx, world, road.To)
+			if err != nil {
+				return roads, err
+			}
+
+			length := road.Length
+			if length == 0 {
+				length = 1
+			}
+
+			err = world.AddWeightedLink(ctx, citiesFrom[i], cityTo, record.directions[j], length)
+			if err != nil {
+				return roads, err
+			}
+			roads++
+		}
+	}
+
+	return roads, nil
+}
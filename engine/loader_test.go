@@ -0,0 +1,201 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+var benchGrid = flag.Int("grid", 300, "width and height of the grid map loaded by the benchmarks, 3000 loads about 36 million roads")
+
+// writtenWorld writes a world in the classic format, for comparisons
+func writtenWorld(t testing.TB, world World) string {
+	out := &bytes.Buffer{}
+	err := mapfile.NewMapWriter(out).WriteWorld(context.Background(), world)
+	require.NoError(t, err)
+	return out.String()
+}
+
+func Test_StreamLoader_SameAsLoadWorld(t *testing.T) {
+	ctx := context.Background()
+
+	for _, path := range []string{"../test_data/test_map", "../test_data/test_map2"} {
+		t.Run(path, func(t *testing.T) {
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			s := NewEngine(0, 10, bytes.NewReader(content), &bytes.Buffer{})
+			require.NoError(t, s.loadWorld(ctx))
+
+			// seekable input
+			streamed := NewWorld()
+			err = NewStreamLoader(streamed).Load(ctx, bytes.NewReader(content))
+			require.NoError(t, err)
+			require.Equal(t, writtenWorld(t, s.world), writtenWorld(t, streamed))
+
+			// non-seekable input is spooled
+			spooled := NewWorld()
+			err = NewStreamLoader(spooled).Load(ctx, io.MultiReader(bytes.NewReader(content)))
+			require.NoError(t, err)
+			require.Equal(t, writtenWorld(t, s.world), writtenWorld(t, spooled))
+
+			// through the engine
+			e := NewEngine(0, 10, bytes.NewReader(content), &bytes.Buffer{}, WithStreamingLoader())
+			require.NoError(t, e.loadWorld(ctx))
+			require.Equal(t, writtenWorld(t, s.world), writtenWorld(t, e.world))
+		})
+	}
+}
+
+func Test_StreamLoader_LongLines(t *testing.T) {
+	ctx := context.Background()
+
+	// a city with a 100KB line, beyond the former 64KB limit
+	var line strings.Builder
+	line.WriteString("Hub")
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&line, " road%d=City%d", i, i)
+	}
+	input := line.String() + "\nCity1 east=Hub\n"
+	require.Greater(t, len(input), 64*1024)
+
+	world := NewWorld()
+	err := NewStreamLoader(world, WithLoaderDirections(types.FreeFormDirections), WithBufferSize(1024)).Load(ctx, strings.NewReader(input))
+	require.NoError(t, err)
+
+	hub, err := world.GetCity(ctx, "Hub")
+	require.NoError(t, err)
+	require.Len(t, hub.Links, 5000)
+
+	// the maximum line size is enforced
+	err = NewStreamLoader(NewWorld(), WithLoaderDirections(types.FreeFormDirections), WithMaxLineSize(32*1024)).Load(ctx, strings.NewReader(input))
+	require.ErrorIs(t, err, types.ERR_LINE_TOO_LONG)
+
+	s := NewEngine(0, 10, strings.NewReader(input), &bytes.Buffer{}, WithDirectionProfile(types.FreeFormDirections))
+	require.NoError(t, s.loadWorld(ctx))
+}
+
+func Test_StreamLoader_Progress(t *testing.T) {
+	ctx := context.Background()
+
+	content := &bytes.Buffer{}
+	require.NoError(t, mapfile.WriteGrid(content, 5, 5))
+	size := int64(content.Len())
+
+	var reports []LoadProgress
+	world := NewWorld()
+	err := NewStreamLoader(world, WithProgress(func(p LoadProgress) { reports = append(reports, p) }, 10)).Load(ctx, content)
+	require.NoError(t, err)
+
+	require.Equal(t, []LoadProgress{
+		{Pass: 1, Lines: 10, Bytes: reports[0].Bytes, Cities: 10},
+		{Pass: 1, Lines: 20, Bytes: reports[1].Bytes, Cities: 20},
+		{Pass: 1, Lines: 25, Bytes: size, Cities: 25, Done: true},
+		{Pass: 2, Lines: 10, Bytes: reports[3].Bytes, Cities: 25, Roads: 31},
+		{Pass: 2, Lines: 20, Bytes: reports[4].Bytes, Cities: 25, Roads: 67},
+		{Pass: 2, Lines: 25, Bytes: size, Cities: 25, Roads: 80, Done: true},
+	}, reports)
+
+	roads, err := world.GetRoads(ctx)
+	require.NoError(t, err)
+	require.Len(t, roads, 40)
+}
+
+func Test_StreamLoader_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name, input string
+		wantError   error
+	}{
+		{"Malformed road", "City1 north=City2=City3\n", types.ERR_PARSE_CITY_DEFINITION},
+		{"Unknown direction", "City1 up=City2\n", types.ERR_PARSE_CITY_DEFINITION},
+		{"Same city", "City1 north=City1\n", types.ERR_LINK_SAME_CITY},
+		{"Conflicting roads", "City1 north=City2 north=City3\n", types.ERR_ALREADY_EXISTS_LINK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewStreamLoader(NewWorld()).Load(ctx, strings.NewReader(tt.input))
+			require.ErrorIs(t, err, tt.wantError)
+		})
+	}
+
+	// structured formats are not streamed but still loaded
+	in, err := os.Open("../test_data/test_map.json")
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	s := NewEngine(0, 10, in, &bytes.Buffer{}, WithStreamingLoader())
+	require.NoError(t, s.loadWorld(ctx))
+	cities, err := s.world.GetAliveCities(ctx)
+	require.NoError(t, err)
+	require.Len(t, cities, 5)
+}
+
+func Test_StreamLoader_Cancelled(t *testing.T) {
+	in, err := os.Open("../test_data/test_map2")
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// cancelled halfway through the first pass
+	world := NewWorld()
+	err = NewStreamLoader(world, WithProgress(func(LoadProgress) { cancel() }, 100)).Load(ctx, in)
+	require.ErrorIs(t, err, context.Canceled)
+
+	cities, err := world.GetCities(context.Background())
+	require.NoError(t, err)
+	require.Len(t, cities, 100)
+}
+
+// generatedGrid writes the benchmark grid map to a temporary file
+func generatedGrid(b *testing.B) string {
+	path := fmt.Sprintf("%s/grid_%d.map", b.TempDir(), *benchGrid)
+	out, err := os.Create(path)
+	require.NoError(b, err)
+	require.NoError(b, mapfile.WriteGrid(out, *benchGrid, *benchGrid))
+	require.NoError(b, out.Close())
+	return path
+}
+
+func Benchmark_StreamLoader_Grid(b *testing.B) {
+	ctx := context.Background()
+	path := generatedGrid(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		in, err := os.Open(path)
+		require.NoError(b, err)
+
+		err = NewStreamLoader(NewWorld()).Load(ctx, in)
+		require.NoError(b, err)
+		_ = in.Close()
+	}
+}
+
+func Benchmark_loadWorld_Grid(b *testing.B) {
+	ctx := context.Background()
+	path := generatedGrid(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		in, err := os.Open(path)
+		require.NoError(b, err)
+
+		s := NewEngine(0, 10, in, &bytes.Buffer{})
+		require.NoError(b, s.loadWorld(ctx))
+		_ = in.Close()
+	}
+}
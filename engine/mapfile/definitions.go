@@ -0,0 +1,35 @@
+package mapfile
+
+import (
+	"alien-invasion-cc/engine/types"
+)
+
+// Map Type definition, the content of a map file
+type Map struct {
+	// Free-form information about the map
+	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	// Cities of the map, in file order
+	Cities []*CityDefinition `json:"cities" yaml:"cities"`
+}
+
+// CityDefinition Type definition, a city and the roads leading out of it
+type CityDefinition struct {
+	// City name
+	Name string `json:"name" yaml:"name"`
+	// Free-form attributes of the city
+	Attributes map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	// Optional position of the city
+	Coordinates *types.Coordinates `json:"coordinates,omitempty" yaml:"coordinates,omitempty"`
+	// Roads leading out of the city
+	Roads []RoadDefinition `json:"roads,omitempty" yaml:"roads,omitempty"`
+}
+
+// RoadDefinition Type definition, a road leading out of a city
+type RoadDefinition struct {
+	// Direction name of the road
+	Direction string `json:"direction" yaml:"direction"`
+	// Name of the destination city
+	To string `json:"to" yaml:"to"`
+	// Number of steps needed to travel the road, one when omitted
+	Length uint `json:"length,omitempty" yaml:"length,omitempty"`
+}
@@ -0,0 +1,71 @@
+package mapfile
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// StdinPath is the map file path reading the standard input
+	StdinPath = "-"
+	// GzipExtension ends the paths of gzip-compressed map files
+	GzipExtension = ".gz"
+)
+
+// gzipFile is a decompressed map file, closing the file with the decompressor
+type gzipFile struct {
+	*gzip.Reader
+	file io.Closer
+}
+
+// Close closes the decompressor and the file
+func (f *gzipFile) Close() error {
+	err := f.Reader.Close()
+	closeErr := f.file.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// Open opens a map file, StdinPath reading stdin, a file ending with GzipExtension being decompressed
+func Open(path string, stdin io.Reader) (io.ReadCloser, error) {
+	var file io.ReadCloser
+	if path == StdinPath {
+		file = io.NopCloser(stdin)
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		file = f
+	}
+
+	if !IsGzip(path) {
+		return file, nil
+	}
+
+	decompressed, err := gzip.NewReader(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &gzipFile{Reader: decompressed, file: file}, nil
+}
+
+// IsGzip checks if a map file path names a gzip-compressed file
+func IsGzip(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), GzipExtension)
+}
+
+// trimGzip removes the gzip extension of a path, map.json.gz becoming map.json
+func trimGzip(path string) string {
+	if IsGzip(path) {
+		return path[:len(path)-len(GzipExtension)]
+	}
+	return path
+}
@@ -0,0 +1,49 @@
+package mapfile
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Open(t *testing.T) {
+	dir := t.TempDir()
+	content := "Foo north=Bar\nBar south=Foo\n"
+
+	plain := filepath.Join(dir, "world.map")
+	require.NoError(t, os.WriteFile(plain, []byte(content), 0o644))
+
+	compressed := filepath.Join(dir, "world.map.gz")
+	f, err := os.Create(compressed)
+	require.NoError(t, err)
+	w := gzip.NewWriter(f)
+	_, err = w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	for _, path := range []string{plain, compressed, StdinPath} {
+		in, err := Open(path, strings.NewReader(content))
+		require.NoError(t, err, path)
+
+		read, err := io.ReadAll(in)
+		require.NoError(t, err, path)
+		require.Equal(t, content, string(read), path)
+		require.NoError(t, in.Close(), path)
+	}
+
+	// a file named .gz must be compressed
+	_, err = Open(plain, nil)
+	require.NoError(t, err)
+	require.NoError(t, os.Rename(plain, plain+".gz"))
+	_, err = Open(plain+".gz", nil)
+	require.ErrorIs(t, err, gzip.ErrHeader)
+
+	_, err = Open(filepath.Join(dir, "missing.map"), nil)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
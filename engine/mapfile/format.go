@@ -0,0 +1,99 @@
+package mapfile
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"alien-invasion-cc/engine/types"
+)
+
+// Format Type definition
+type Format string
+
+const (
+	// FormatAuto detects the format from the file extension or content
+	FormatAuto Format = "auto"
+	// FormatClassic is the whitespace-separated text format, one city per line
+	FormatClassic Format = "classic"
+	// FormatJSON is the structured JSON format
+	FormatJSON Format = "json"
+	// FormatYAML is the structured YAML format
+	FormatYAML Format = "yaml"
+)
+
+// sniffSize is the number of bytes looked at to detect a format
+const sniffSize = 512
+
+// ParseFormat retrieves a format by name
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "", string(FormatAuto):
+		return FormatAuto, nil
+	case string(FormatClassic), "text", "txt":
+		return FormatClassic, nil
+	case string(FormatJSON):
+		return FormatJSON, nil
+	case string(FormatYAML), "yml":
+		return FormatYAML, nil
+	default:
+		return FormatAuto, types.ERR_UNKNOWN_MAP_FORMAT
+	}
+}
+
+// FormatFromPath detects a format from a file extension, FormatAuto when unknown, the extension of a
+// gzip-compressed file being looked at before .gz
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(trimGzip(path))) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".txt", ".map":
+		return FormatClassic
+	default:
+		return FormatAuto
+	}
+}
+
+// DetectFormat detects a format by looking at the beginning of the content
+func DetectFormat(in *bufio.Reader) Format {
+	head, _ := in.Peek(sniffSize)
+	head = bytes.TrimLeft(head, " \t\r\n\ufeff")
+
+	switch {
+	case len(head) == 0:
+		return FormatClassic
+	case head[0] == '{' || head[0] == '[':
+		return FormatJSON
+	case bytes.HasPrefix(head, []byte("---")),
+		bytes.HasPrefix(head, []byte("cities:")),
+		bytes.HasPrefix(head, []byte("metadata:")):
+		return FormatYAML
+	default:
+		return FormatClassic
+	}
+}
+
+// SniffFormat detects the format of a content and retrieves a reader replaying the whole content
+func SniffFormat(in io.Reader) (Format, io.Reader, error) {
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(in, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return FormatAuto, nil, err
+	}
+	head = head[:n]
+
+	format := DetectFormat(bufio.NewReader(bytes.NewReader(head)))
+
+	if seeker, ok := in.(io.ReadSeeker); ok {
+		_, err = seeker.Seek(-int64(n), io.SeekCurrent)
+		if err == nil {
+			return format, seeker, nil
+		}
+	}
+
+	return format, io.MultiReader(bytes.NewReader(head), in), nil
+}
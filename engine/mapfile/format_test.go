@@ -0,0 +1,65 @@
+package mapfile
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantFormat Format
+		wantError  error
+	}{
+		{"", FormatAuto, nil},
+		{"auto", FormatAuto, nil},
+		{"classic", FormatClassic, nil},
+		{"TXT", FormatClassic, nil},
+		{"json", FormatJSON, nil},
+		{"yml", FormatYAML, nil},
+		{"xml", FormatAuto, types.ERR_UNKNOWN_MAP_FORMAT},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := ParseFormat(tt.name)
+			require.Equal(t, tt.wantError, err)
+			require.Equal(t, tt.wantFormat, format)
+		})
+	}
+}
+
+func Test_FormatFromPath(t *testing.T) {
+	require.Equal(t, FormatJSON, FormatFromPath("maps/world.JSON"))
+	require.Equal(t, FormatYAML, FormatFromPath("world.yaml"))
+	require.Equal(t, FormatYAML, FormatFromPath("world.yml"))
+	require.Equal(t, FormatClassic, FormatFromPath("world.txt"))
+	require.Equal(t, FormatAuto, FormatFromPath("test_data/test_map"))
+	require.Equal(t, FormatJSON, FormatFromPath("world.json.gz"))
+	require.Equal(t, FormatClassic, FormatFromPath("world.map.GZ"))
+	require.Equal(t, FormatAuto, FormatFromPath("test_map.gz"))
+}
+
+func Test_DetectFormat(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantFormat Format
+	}{
+		{"", FormatClassic},
+		{"Foo north=Bar\n", FormatClassic},
+		{"  \n{\"cities\": []}", FormatJSON},
+		{"---\ncities: []\n", FormatYAML},
+		{"metadata:\n  name: x\n", FormatYAML},
+		{"cities:\n  - name: Foo\n", FormatYAML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			require.Equal(t, tt.wantFormat, DetectFormat(bufio.NewReader(strings.NewReader(tt.input))))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package mapfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// WriteGrid writes a width x height grid map in the classic format, every city linked to its four neighbours
+func WriteGrid(out io.Writer, width, height int) error {
+	w := bufio.NewWriter(out)
+
+	for y := 1; y <= height; y++ {
+		for x := 1; x <= width; x++ {
+			_, err := fmt.Fprintf(w, "City_%d_%d", x, y)
+			if err != nil {
+				return err
+			}
+
+			neighbours := []struct {
+				direction string
+				x, y      int
+			}{
+				{"north", x, y - 1},
+				{"east", x + 1, y},
+				{"south", x, y + 1},
+				{"west", x - 1, y},
+			}
+
+			for _, neighbour := range neighbours {
+				if neighbour.x < 1 || neighbour.x > width || neighbour.y < 1 || neighbour.y > height {
+					continue
+				}
+
+				_, err = fmt.Fprintf(w, " %s=City_%d_%d", neighbour.direction, neighbour.x, neighbour.y)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = w.WriteByte('\n')
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.Flush()
+}
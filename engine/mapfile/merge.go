@@ -0,0 +1,77 @@
+package mapfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"alien-invasion-cc/engine/types"
+)
+
+// Source Type definition, a map read with the name of the file it comes from
+type Source struct {
+	// Name of the map, its file path
+	Name string
+	// Reader of the map
+	Reader MapReader
+}
+
+// mergedReader reads several maps one after the other, as a single map
+type mergedReader struct {
+	sources []Source
+	next    int
+	// index of the map defining every city met so far
+	definedIn map[string]int
+}
+
+// NewMergedReader reads several maps as a single map, their cities in map order; a city defined in two maps
+// fails with ERR_DUPLICATE_CITY, roads may lead to a city defined in another map
+func NewMergedReader(sources ...Source) MapReader {
+	return &mergedReader{
+		sources:   sources,
+		definedIn: make(map[string]int),
+	}
+}
+
+// Next retrieves the next city definition
+func (r *mergedReader) Next(ctx context.Context) (*CityDefinition, error) {
+	for r.next < len(r.sources) {
+		source := r.sources[r.next]
+
+		city, err := source.Reader.Next(ctx)
+		if err == io.EOF {
+			r.next++
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source.Name, err)
+		}
+
+		if i, found := r.definedIn[city.Name]; found && i != r.next {
+			return nil, fmt.Errorf("%w: %s is defined in both %s and %s", types.ERR_DUPLICATE_CITY, city.Name, r.sources[i].Name, source.Name)
+		}
+		r.definedIn[city.Name] = r.next
+
+		return city, nil
+	}
+
+	return nil, io.EOF
+}
+
+// Metadata retrieves the metadata of the maps, the first map setting a key taking precedence
+func (r *mergedReader) Metadata() map[string]string {
+	var metadata map[string]string
+	for _, source := range r.sources {
+		for key, value := range source.Reader.Metadata() {
+			if metadata == nil {
+				metadata = make(map[string]string)
+			}
+
+			if _, found := metadata[key]; !found {
+				metadata[key] = value
+			}
+		}
+	}
+	return metadata
+}
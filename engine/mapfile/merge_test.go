@@ -0,0 +1,58 @@
+package mapfile
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewMergedReader(t *testing.T) {
+	ctx := context.Background()
+
+	west, err := NewJSONReader(strings.NewReader(`{"metadata": {"name": "west", "scale": "1"}, "cities": [{"name": "Paris", "roads": [{"direction": "east", "to": "Berlin"}]}]}`))
+	require.NoError(t, err)
+	east := NewClassicReader(strings.NewReader("Berlin west=Paris\nWarsaw west=Berlin\n"))
+
+	m, err := ReadAll(ctx, NewMergedReader(Source{Name: "west.json", Reader: west}, Source{Name: "east.map", Reader: east}))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"name": "west", "scale": "1"}, m.Metadata)
+	require.Equal(t, []*CityDefinition{
+		{Name: "Paris", Roads: []RoadDefinition{{Direction: "east", To: "Berlin"}}},
+		{Name: "Berlin", Roads: []RoadDefinition{{Direction: "west", To: "Paris"}}},
+		{Name: "Warsaw", Roads: []RoadDefinition{{Direction: "west", To: "Berlin"}}},
+	}, m.Cities)
+
+	// a city defined twice in the same map is left to the loader, as with a single map
+	_, err = ReadAll(ctx, NewMergedReader(Source{Name: "a.map", Reader: NewClassicReader(strings.NewReader("Foo north=Bar\nFoo south=Baz\n"))}))
+	require.NoError(t, err)
+
+	_, err = ReadAll(ctx, NewMergedReader(
+		Source{Name: "a.map", Reader: NewClassicReader(strings.NewReader("Foo north=Bar\n"))},
+		Source{Name: "a.map", Reader: NewClassicReader(strings.NewReader("Bar south=Foo\nFoo south=Baz\n"))},
+	))
+	require.ErrorIs(t, err, types.ERR_DUPLICATE_CITY)
+	require.EqualError(t, err, "duplicate city name exists: Foo is defined in both a.map and a.map")
+
+	_, err = ReadAll(ctx, NewMergedReader(Source{Name: "bad.map", Reader: NewClassicReader(strings.NewReader("Foo north\n"))}))
+	require.ErrorIs(t, err, types.ERR_PARSE_CITY_DEFINITION)
+	require.True(t, strings.HasPrefix(err.Error(), "bad.map: "), err.Error())
+
+	m, err = ReadAll(ctx, NewMergedReader())
+	require.NoError(t, err)
+	require.Empty(t, m.Cities)
+	require.Nil(t, m.Metadata)
+}
+
+func Test_NewDocumentReader(t *testing.T) {
+	ctx := context.Background()
+
+	m := &Map{Cities: []*CityDefinition{{Name: "Foo"}, {Name: "Bar"}}}
+	for i := 0; i < 2; i++ {
+		read, err := ReadAll(ctx, NewDocumentReader(m))
+		require.NoError(t, err)
+		require.Equal(t, m.Cities, read.Cities)
+	}
+}
@@ -0,0 +1,208 @@
+package mapfile
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"alien-invasion-cc/engine/types"
+)
+
+// MapReader reads a map one city at a time
+type MapReader interface {
+	// Next retrieves the next city definition, io.EOF once the whole map is read
+	Next(ctx context.Context) (*CityDefinition, error)
+	// Metadata retrieves the map metadata
+	Metadata() map[string]string
+}
+
+// NewMapReader creates the reader of a given format, FormatAuto detects it from the content
+func NewMapReader(in io.Reader, format Format) (MapReader, error) {
+	buffered := bufio.NewReader(in)
+	if format == FormatAuto || format == "" {
+		format = DetectFormat(buffered)
+	}
+
+	switch format {
+	case FormatClassic:
+		return NewClassicReader(buffered), nil
+	case FormatJSON:
+		return NewJSONReader(buffered)
+	case FormatYAML:
+		return NewYAMLReader(buffered)
+	default:
+		return nil, types.ERR_UNKNOWN_MAP_FORMAT
+	}
+}
+
+// ReadAll reads a whole map
+func ReadAll(ctx context.Context, r MapReader) (*Map, error) {
+	m := &Map{
+		Metadata: r.Metadata(),
+		Cities:   []*CityDefinition{},
+	}
+
+	for {
+		city, err := r.Next(ctx)
+		if err == io.EOF {
+			return m, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		m.Cities = append(m.Cities, city)
+	}
+}
+
+// CommentPrefix starts the lines of the classic format which are not city definitions
+const CommentPrefix = "#"
+
+// ClassicReader reads the classic text format: a city name followed by direction=City pairs
+type ClassicReader struct {
+	scanner *LineScanner
+}
+
+var _ MapReader = (*ClassicReader)(nil)
+
+// Generate New ClassicReader
+func NewClassicReader(in io.Reader) *ClassicReader {
+	return NewClassicReaderSize(in, DefaultBufferSize, DefaultMaxLineSize)
+}
+
+// NewClassicReaderSize creates a ClassicReader with a given buffer size and maximum line size
+func NewClassicReaderSize(in io.Reader, bufferSize, maxLineSize int) *ClassicReader {
+	return &ClassicReader{
+		scanner: NewLineScanner(in, bufferSize, maxLineSize),
+	}
+}
+
+// Next retrieves the next city definition
+func (r *ClassicReader) Next(ctx context.Context) (*CityDefinition, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if r.scanner.Scan() {
+		return ParseClassicLine(r.scanner.Text())
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
+
+// Metadata retrieves the map metadata, the classic format has none
+func (r *ClassicReader) Metadata() map[string]string {
+	return nil
+}
+
+// ParseClassicLine parses a city definition in the classic format
+func ParseClassicLine(line string) (*CityDefinition, error) {
+	lineChunks := strings.Split(line, " ")
+	if len(lineChunks) == 0 || lineChunks[0] == "" {
+		return nil, types.ERR_PARSE_CITY_DEFINITION
+	}
+
+	city := &CityDefinition{
+		Name: lineChunks[0],
+	}
+
+	for _, lineChunk := range lineChunks[1:] {
+		linkChunks := strings.Split(strings.TrimSpace(lineChunk), "=")
+		if len(linkChunks) != 2 {
+			return nil, types.ERR_PARSE_CITY_DEFINITION
+		}
+
+		road := RoadDefinition{
+			Direction: linkChunks[0],
+		}
+
+		// the destination may be followed by the length of the road: Bar:3
+		targetChunks := strings.Split(linkChunks[1], ":")
+		switch len(targetChunks) {
+		case 1:
+			road.To = targetChunks[0]
+		case 2:
+			length, err := strconv.ParseUint(targetChunks[1], 10, 32)
+			if err != nil || length == 0 {
+				return nil, types.ERR_PARSE_CITY_DEFINITION
+			}
+			road.To = targetChunks[0]
+			road.Length = uint(length)
+		default:
+			return nil, types.ERR_PARSE_CITY_DEFINITION
+		}
+
+		city.Roads = append(city.Roads, road)
+	}
+
+	return city, nil
+}
+
+// documentReader iterates over the cities of a fully decoded map
+type documentReader struct {
+	m    *Map
+	next int
+}
+
+// Next retrieves the next city definition
+func (r *documentReader) Next(ctx context.Context) (*CityDefinition, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if r.next >= len(r.m.Cities) {
+		return nil, io.EOF
+	}
+
+	city := r.m.Cities[r.next]
+	r.next++
+
+	if city == nil || city.Name == "" {
+		return nil, types.ERR_PARSE_CITY_DEFINITION
+	}
+
+	return city, nil
+}
+
+// Metadata retrieves the map metadata
+func (r *documentReader) Metadata() map[string]string {
+	return r.m.Metadata
+}
+
+// NewDocumentReader reads the cities of a decoded map, a map read once being read again this way
+func NewDocumentReader(m *Map) MapReader {
+	return &documentReader{m: m}
+}
+
+// NewJSONReader decodes a map in the JSON format
+func NewJSONReader(in io.Reader) (MapReader, error) {
+	m := &Map{}
+	err := json.NewDecoder(in).Decode(m)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ERR_PARSE_CITY_DEFINITION, err)
+	}
+
+	return &documentReader{m: m}, nil
+}
+
+// NewYAMLReader decodes a map in the YAML format
+func NewYAMLReader(in io.Reader) (MapReader, error) {
+	m := &Map{}
+	err := yaml.NewDecoder(in).Decode(m)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("%w: %v", types.ERR_PARSE_CITY_DEFINITION, err)
+	}
+
+	return &documentReader{m: m}, nil
+}
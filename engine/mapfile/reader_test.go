@@ -0,0 +1,116 @@
+package mapfile
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ClassicReader(t *testing.T) {
+	ctx := context.Background()
+
+	input := `
+City1 north=City2 east=City3:4
+
+	City2 south=City1
+City3
+`
+	m, err := ReadAll(ctx, NewClassicReader(strings.NewReader(input)))
+	require.NoError(t, err)
+	require.Nil(t, m.Metadata)
+	require.Equal(t, []*CityDefinition{
+		{Name: "City1", Roads: []RoadDefinition{{Direction: "north", To: "City2"}, {Direction: "east", To: "City3", Length: 4}}},
+		{Name: "City2", Roads: []RoadDefinition{{Direction: "south", To: "City1"}}},
+		{Name: "City3"},
+	}, m.Cities)
+}
+
+func Test_ParseClassicLine(t *testing.T) {
+	tests := []struct {
+		line      string
+		wantError error
+	}{
+		{"City1 north=City2", nil},
+		{"City1 north=City2:2", nil},
+		{"City1 north", types.ERR_PARSE_CITY_DEFINITION},
+		{"City1 north=City2=City3", types.ERR_PARSE_CITY_DEFINITION},
+		{"City1 north=City2:0", types.ERR_PARSE_CITY_DEFINITION},
+		{"City1 north=City2:-1", types.ERR_PARSE_CITY_DEFINITION},
+		{"City1 north=City2:1:2", types.ERR_PARSE_CITY_DEFINITION},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			_, err := ParseClassicLine(tt.line)
+			require.ErrorIs(t, err, tt.wantError)
+		})
+	}
+}
+
+func Test_StructuredReaders(t *testing.T) {
+	ctx := context.Background()
+
+	for _, path := range []string{"../../test_data/test_map.json", "../../test_data/test_map.yaml"} {
+		t.Run(path, func(t *testing.T) {
+			in, err := os.Open(path)
+			require.NoError(t, err)
+			defer func() { _ = in.Close() }()
+
+			r, err := NewMapReader(in, FormatAuto)
+			require.NoError(t, err)
+
+			m, err := ReadAll(ctx, r)
+			require.NoError(t, err)
+			require.Equal(t, map[string]string{"name": "Europe", "version": "1"}, m.Metadata)
+			require.Len(t, m.Cities, 5)
+
+			paris := m.Cities[0]
+			require.Equal(t, "Paris", paris.Name)
+			require.Equal(t, map[string]string{"country": "France"}, paris.Attributes)
+			require.Equal(t, &types.Coordinates{X: 2.35, Y: 48.85}, paris.Coordinates)
+			require.Equal(t, []RoadDefinition{
+				{Direction: "north", To: "Brussels"},
+				{Direction: "west", To: "Le Havre", Length: 2},
+				{Direction: "east", To: "Berlin", Length: 3},
+			}, paris.Roads)
+
+			require.Equal(t, "Le Havre", m.Cities[1].Name)
+			require.Nil(t, m.Cities[3].Roads)
+		})
+	}
+}
+
+func Test_StructuredReaders_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name, input string
+		format      Format
+		// decoder error the parse error carries, if any
+		cause string
+	}{
+		{"JSON syntax", `{"cities": [`, FormatJSON, "unexpected EOF"},
+		{"JSON type", `{"cities": 3}`, FormatJSON, "json: cannot unmarshal number"},
+		{"JSON unnamed city", `{"cities": [{"roads": []}]}`, FormatJSON, ""},
+		{"YAML syntax", "cities:\n  - name: [", FormatYAML, "yaml: line 2: did not find expected node content"},
+		{"YAML unnamed city", "cities:\n  - roads: []\n", FormatYAML, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewMapReader(strings.NewReader(tt.input), tt.format)
+			if err == nil {
+				_, err = ReadAll(ctx, r)
+			}
+			require.ErrorIs(t, err, types.ERR_PARSE_CITY_DEFINITION)
+			require.Contains(t, err.Error(), tt.cause)
+		})
+	}
+
+	_, err := NewMapReader(strings.NewReader(""), Format("xml"))
+	require.ErrorIs(t, err, types.ERR_UNKNOWN_MAP_FORMAT)
+}
@@ -0,0 +1,104 @@
+package mapfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"alien-invasion-cc/engine/types"
+)
+
+const (
+	// DefaultBufferSize is the initial size of the buffer lines are read into
+	DefaultBufferSize = 64 * 1024
+	// DefaultMaxLineSize is the length beyond which a line is rejected
+	DefaultMaxLineSize = 16 * 1024 * 1024
+)
+
+// LineScanner reads the meaningful lines of a classic map, skipping blank lines and comments
+type LineScanner struct {
+	scanner *bufio.Scanner
+	line    int64
+	offset  int64
+	advance int
+}
+
+// Generate New LineScanner, a size lower than or equal to zero takes the default
+func NewLineScanner(in io.Reader, bufferSize, maxLineSize int) *LineScanner {
+	return NewLineScannerAt(in, bufferSize, maxLineSize, 0)
+}
+
+// NewLineScannerAt creates a LineScanner over a part of a map, skipped lines lines after its start
+func NewLineScannerAt(in io.Reader, bufferSize, maxLineSize int, skipped int64) *LineScanner {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+
+	if maxLineSize <= 0 {
+		maxLineSize = DefaultMaxLineSize
+	}
+
+	if bufferSize > maxLineSize {
+		bufferSize = maxLineSize
+	}
+
+	s := &LineScanner{
+		scanner: bufio.NewScanner(in),
+		line:    skipped,
+	}
+	s.scanner.Buffer(make([]byte, 0, bufferSize), maxLineSize)
+	s.scanner.Split(s.scanLines)
+	return s
+}
+
+// scanLines splits lines as bufio.ScanLines does, keeping the number of bytes the line took with its end,
+// \r\n and a last line with no end included
+func (s *LineScanner) scanLines(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := bufio.ScanLines(data, atEOF)
+	if token != nil {
+		s.advance = advance
+	}
+	return advance, token, err
+}
+
+// Scan advances to the next meaningful line, returns false at the end of the input or on error
+func (s *LineScanner) Scan() bool {
+	for s.scanner.Scan() {
+		s.line++
+		s.offset += int64(s.advance)
+
+		line := strings.TrimSpace(s.scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, CommentPrefix) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// Text retrieves the current line, trimmed
+func (s *LineScanner) Text() string {
+	return strings.TrimSpace(s.scanner.Text())
+}
+
+// Line retrieves the number of the current line, starting at one
+func (s *LineScanner) Line() int64 {
+	return s.line
+}
+
+// Offset retrieves the number of bytes read so far
+func (s *LineScanner) Offset() int64 {
+	return s.offset
+}
+
+// Err retrieves the error which stopped the scan, nil at the end of the input
+func (s *LineScanner) Err() error {
+	err := s.scanner.Err()
+	if err == bufio.ErrTooLong {
+		return fmt.Errorf("%w: after line %d", types.ERR_LINE_TOO_LONG, s.line)
+	}
+	return err
+}
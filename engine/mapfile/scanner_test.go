@@ -0,0 +1,35 @@
+package mapfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LineScanner_Offset(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantLines []string
+	}{
+		{"LF", "City1 north=City2\n# comment\n\nCity2\n", []string{"City1 north=City2", "City2"}},
+		{"CRLF", "City1 north=City2\r\n# comment\r\n\r\nCity2\r\n", []string{"City1 north=City2", "City2"}},
+		{"No final line end", "City1 north=City2\nCity2", []string{"City1 north=City2", "City2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := NewLineScanner(strings.NewReader(tt.input), 0, 0)
+			lines := []string{}
+			for scanner.Scan() {
+				lines = append(lines, scanner.Text())
+			}
+			require.NoError(t, scanner.Err())
+			require.Equal(t, tt.wantLines, lines)
+
+			// the offset is the number of bytes consumed, line ends included
+			require.Equal(t, int64(len(tt.input)), scanner.Offset())
+		})
+	}
+}
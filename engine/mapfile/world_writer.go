@@ -0,0 +1,151 @@
+package mapfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"alien-invasion-cc/engine/types"
+)
+
+// CitySource lists the cities of a world, destroyed ones included, in the order they were added
+type CitySource interface {
+	GetCities(ctx context.Context) ([]*types.City, error)
+}
+
+// MapWriter writes a world back in the classic format
+type MapWriter struct {
+	out io.Writer
+
+	sorted bool
+
+	includeDestroyed bool
+}
+
+// WriterOption configures a MapWriter
+type WriterOption func(*MapWriter)
+
+// WithSortedCities writes cities sorted by name instead of in input order
+func WithSortedCities(enabled bool) WriterOption {
+	return func(w *MapWriter) {
+		w.sorted = enabled
+	}
+}
+
+// WithDestroyedCities writes destroyed cities as comments
+func WithDestroyedCities(enabled bool) WriterOption {
+	return func(w *MapWriter) {
+		w.includeDestroyed = enabled
+	}
+}
+
+// Generate New MapWriter
+func NewMapWriter(out io.Writer, opts ...WriterOption) *MapWriter {
+	w := &MapWriter{
+		out: out,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// WriteWorld writes every city of a world with the roads leading out of it
+func (w *MapWriter) WriteWorld(ctx context.Context, world CitySource) error {
+	cities, err := w.cities(ctx, world)
+	if err != nil {
+		return err
+	}
+
+	for _, city := range cities {
+		if city.IsDestroyed {
+			_, err = fmt.Fprintf(w.out, "%s %s destroyed\n", CommentPrefix, city.Name)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		line, err := FormatClassicLine(NewCityDefinition(city))
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(w.out, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cities retrieves the cities to write, in writing order
+func (w *MapWriter) cities(ctx context.Context, world CitySource) ([]*types.City, error) {
+	all, err := world.GetCities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cities := make([]*types.City, 0, len(all))
+	for _, city := range all {
+		if city.IsDestroyed && !w.includeDestroyed {
+			continue
+		}
+		cities = append(cities, city)
+	}
+
+	if w.sorted {
+		sort.SliceStable(cities, func(i, j int) bool { return cities[i].Name < cities[j].Name })
+	}
+
+	return cities, nil
+}
+
+// NewCityDefinition describes a city and the roads leading out of it
+func NewCityDefinition(city *types.City) *CityDefinition {
+	definition := &CityDefinition{
+		Name:        city.Name,
+		Attributes:  city.Attributes,
+		Coordinates: city.Coordinates,
+	}
+
+	for _, direction := range city.Directions() {
+		road := RoadDefinition{
+			Direction: direction.String(),
+			To:        city.Links[direction].Name,
+		}
+
+		if length, err := city.GetLinkLength(direction); err == nil && length > 1 {
+			road.Length = length
+		}
+
+		definition.Roads = append(definition.Roads, road)
+	}
+
+	return definition
+}
+
+// NewMap describes the alive cities of a world, for the structured formats
+func NewMap(ctx context.Context, world CitySource, metadata map[string]string) (*Map, error) {
+	cities, err := world.GetCities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Map{
+		Metadata: metadata,
+		Cities:   []*CityDefinition{},
+	}
+
+	for _, city := range cities {
+		if !city.IsDestroyed {
+			m.Cities = append(m.Cities, NewCityDefinition(city))
+		}
+	}
+
+	return m, nil
+}
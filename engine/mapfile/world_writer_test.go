@@ -0,0 +1,102 @@
+package mapfile
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+type citySourceStub struct {
+	cities []*types.City
+	err    error
+}
+
+func (s *citySourceStub) GetCities(ctx context.Context) ([]*types.City, error) {
+	return s.cities, s.err
+}
+
+func Test_MapWriter_WriteWorld(t *testing.T) {
+	ctx := context.Background()
+
+	paris := types.NewCity("Paris")
+	berlin := types.NewCity("Berlin")
+	athens := types.NewCity("Athens")
+	rome := types.NewCity("Rome")
+	require.NoError(t, paris.SetCityLink(berlin, types.East))
+	require.NoError(t, paris.SetLinkLength(types.East, 3))
+	require.NoError(t, berlin.SetCityLink(paris, types.West))
+	require.NoError(t, berlin.SetLinkLength(types.West, 3))
+	require.NoError(t, rome.SetCityLink(paris, types.North))
+	athens.IsDestroyed = true
+
+	source := &citySourceStub{cities: []*types.City{paris, berlin, athens, rome}}
+
+	tests := []struct {
+		name string
+		opts []WriterOption
+		want string
+	}{
+		{
+			name: "Input order",
+			want: "Paris east=Berlin:3\nBerlin west=Paris:3\nRome north=Paris\n",
+		},
+		{
+			name: "Sorted",
+			opts: []WriterOption{WithSortedCities(true)},
+			want: "Berlin west=Paris:3\nParis east=Berlin:3\nRome north=Paris\n",
+		},
+		{
+			name: "Destroyed cities as comments",
+			opts: []WriterOption{WithDestroyedCities(true)},
+			want: "Paris east=Berlin:3\nBerlin west=Paris:3\n# Athens destroyed\nRome north=Paris\n",
+		},
+		{
+			name: "Sorted with destroyed cities",
+			opts: []WriterOption{WithSortedCities(true), WithDestroyedCities(true)},
+			want: "# Athens destroyed\nBerlin west=Paris:3\nParis east=Berlin:3\nRome north=Paris\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			err := NewMapWriter(out, tt.opts...).WriteWorld(ctx, source)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, out.String())
+
+			// the written map is read back, comments skipped
+			m, err := ReadAll(ctx, NewClassicReader(out))
+			require.NoError(t, err)
+			require.Len(t, m.Cities, 3)
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		error1 := fmt.Errorf("error 1")
+		err := NewMapWriter(&bytes.Buffer{}).WriteWorld(ctx, &citySourceStub{err: error1})
+		require.ErrorIs(t, err, error1)
+
+		err = NewMapWriter(&bytes.Buffer{}).WriteWorld(ctx, &citySourceStub{cities: []*types.City{types.NewCity("Le Havre")}})
+		require.ErrorIs(t, err, types.ERR_UNREPRESENTABLE_NAME)
+	})
+}
+
+func Test_NewMap(t *testing.T) {
+	ctx := context.Background()
+
+	paris := types.NewCity("Paris")
+	paris.Attributes = map[string]string{"country": "France"}
+	athens := types.NewCity("Athens")
+	athens.IsDestroyed = true
+
+	m, err := NewMap(ctx, &citySourceStub{cities: []*types.City{paris, athens}}, map[string]string{"step": "10"})
+	require.NoError(t, err)
+	require.Equal(t, &Map{
+		Metadata: map[string]string{"step": "10"},
+		Cities:   []*CityDefinition{{Name: "Paris", Attributes: map[string]string{"country": "France"}}},
+	}, m)
+}
@@ -0,0 +1,82 @@
+package mapfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"alien-invasion-cc/engine/types"
+)
+
+// Encode writes a map in a given format
+func Encode(out io.Writer, format Format, m *Map) error {
+	switch format {
+	case FormatClassic:
+		return encodeClassic(out, m)
+	case FormatJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(m)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(out)
+		encoder.SetIndent(2)
+		err := encoder.Encode(m)
+		if err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return types.ERR_UNKNOWN_MAP_FORMAT
+	}
+}
+
+// encodeClassic writes a map in the classic format, attributes, coordinates and metadata are dropped
+func encodeClassic(out io.Writer, m *Map) error {
+	for _, city := range m.Cities {
+		line, err := FormatClassicLine(city)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(out, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FormatClassicLine formats a city definition in the classic format
+func FormatClassicLine(city *CityDefinition) (string, error) {
+	if !isClassicName(city.Name) {
+		return "", fmt.Errorf("%w: %q", types.ERR_UNREPRESENTABLE_NAME, city.Name)
+	}
+
+	chunks := []string{city.Name}
+	for _, road := range city.Roads {
+		if !isClassicName(road.Direction) {
+			return "", fmt.Errorf("%w: %q", types.ERR_UNREPRESENTABLE_NAME, road.Direction)
+		}
+
+		if !isClassicName(road.To) {
+			return "", fmt.Errorf("%w: %q", types.ERR_UNREPRESENTABLE_NAME, road.To)
+		}
+
+		chunk := fmt.Sprintf("%s=%s", road.Direction, road.To)
+		if road.Length > 1 {
+			chunk = fmt.Sprintf("%s:%d", chunk, road.Length)
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	return strings.Join(chunks, " "), nil
+}
+
+// isClassicName checks a name can be written in the classic format
+func isClassicName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, "=: \t\r\n#")
+}
@@ -0,0 +1,62 @@
+package mapfile
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Encode_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	m := &Map{
+		Metadata: map[string]string{"name": "Test"},
+		Cities: []*CityDefinition{
+			{
+				Name:        "City1",
+				Attributes:  map[string]string{"size": "big"},
+				Coordinates: &types.Coordinates{X: 1, Y: -2.5},
+				Roads:       []RoadDefinition{{Direction: "north", To: "City2"}, {Direction: "bridge", To: "City3", Length: 2}},
+			},
+			{Name: "City2"},
+			{Name: "City3", Roads: []RoadDefinition{{Direction: "bridge", To: "City1", Length: 2}}},
+		},
+	}
+
+	for _, format := range []Format{FormatJSON, FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			out := &bytes.Buffer{}
+			require.NoError(t, Encode(out, format, m))
+
+			r, err := NewMapReader(out, FormatAuto)
+			require.NoError(t, err)
+
+			read, err := ReadAll(ctx, r)
+			require.NoError(t, err)
+			require.Equal(t, m, read)
+		})
+	}
+
+	t.Run("classic", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		require.NoError(t, Encode(out, FormatClassic, m))
+		require.Equal(t, "City1 north=City2 bridge=City3:2\nCity2\nCity3 bridge=City1:2\n", out.String())
+	})
+}
+
+func Test_Encode_ClassicUnrepresentableName(t *testing.T) {
+	m := &Map{
+		Cities: []*CityDefinition{{Name: "Le Havre"}},
+	}
+
+	err := Encode(&bytes.Buffer{}, FormatClassic, m)
+	require.ErrorIs(t, err, types.ERR_UNREPRESENTABLE_NAME)
+	require.True(t, strings.Contains(err.Error(), "Le Havre"))
+
+	err = Encode(&bytes.Buffer{}, Format("xml"), m)
+	require.ErrorIs(t, err, types.ERR_UNKNOWN_MAP_FORMAT)
+}
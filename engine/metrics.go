@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"time"
+
+	"alien-invasion-cc/metrics"
+)
+
+// Metrics Type definition, the counters and histograms engines report to; engines sharing a
+// registry add up into the same metrics
+type Metrics struct {
+	steps *metrics.Counter
+
+	moves *metrics.Counter
+
+	fights *metrics.Counter
+
+	citiesDestroyed *metrics.Counter
+
+	aliensTrapped *metrics.Counter
+
+	stepDuration *metrics.Histogram
+
+	mapLoads *metrics.Counter
+
+	mapLoadDuration *metrics.Histogram
+
+	mapCities *metrics.Gauge
+
+	mapRoads *metrics.Gauge
+}
+
+// Generate New Metrics registered in a registry
+func NewMetrics(registry *metrics.Registry) *Metrics {
+	return &Metrics{
+		steps:           registry.NewCounter("alien_invasion_steps_total", "Steps executed."),
+		moves:           registry.NewCounter("alien_invasion_moves_total", "Aliens moved into a city, or departed on a road longer than one step."),
+		fights:          registry.NewCounter("alien_invasion_fights_total", "Fights between two aliens, in a city or on a road."),
+		citiesDestroyed: registry.NewCounter("alien_invasion_cities_destroyed_total", "Cities destroyed."),
+		aliensTrapped:   registry.NewCounter("alien_invasion_aliens_trapped_total", "Aliens trapped, killed in a fight or stranded on a road."),
+		stepDuration:    registry.NewHistogram("alien_invasion_step_duration_seconds", "Time taken by a step.", metrics.ExponentialBuckets(0.000001, 4, 12)),
+		mapLoads:        registry.NewCounter("alien_invasion_map_loads_total", "Maps loaded."),
+		mapLoadDuration: registry.NewHistogram("alien_invasion_map_load_duration_seconds", "Time taken to load a map.", metrics.ExponentialBuckets(0.001, 4, 10)),
+		mapCities:       registry.NewGauge("alien_invasion_map_cities", "Cities of the last map loaded."),
+		mapRoads:        registry.NewGauge("alien_invasion_map_roads", "Roads of the last map loaded."),
+	}
+}
+
+// observe counts what an event tells about the simulation
+func (m *Metrics) observe(event Event) {
+	switch event.Type {
+	case EventMoved, EventDeparted:
+		m.moves.Inc()
+	case EventFought, EventRoadFight:
+		m.fights.Inc()
+		m.aliensTrapped.Add(uint64(len(event.Aliens)))
+	case EventStranded:
+		m.aliensTrapped.Add(uint64(len(event.Aliens)))
+	case EventDestroyed:
+		m.citiesDestroyed.Inc()
+	}
+}
+
+// observeStep counts a step which started at a given time
+func (m *Metrics) observeStep(start time.Time) {
+	m.steps.Inc()
+	m.stepDuration.Observe(time.Since(start).Seconds())
+}
+
+// observeMapLoad counts a map load which started at a given time
+func (m *Metrics) observeMapLoad(start time.Time, cities, roads int) {
+	m.mapLoads.Inc()
+	m.mapLoadDuration.Observe(time.Since(start).Seconds())
+	m.mapCities.Set(float64(cities))
+	m.mapRoads.Set(float64(roads))
+}
@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"alien-invasion-cc/metrics"
+)
+
+func Test_Engine_Metrics(t *testing.T) {
+	in, err := os.Open("../test_data/test_map2")
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	var events []Event
+	registry := metrics.NewRegistry()
+	m := NewMetrics(registry)
+	s := NewEngine(40, 100, in, io.Discard, WithSeed(42), WithMetrics(m), WithEventListener(func(e Event) { events = append(events, e) }))
+	require.NoError(t, s.Run(context.Background()))
+
+	counts := map[EventType]uint64{}
+	for _, event := range events {
+		counts[event.Type]++
+	}
+
+	// the metrics add up to the events of the simulation
+	require.Equal(t, uint64(s.totalMoves), m.steps.Value())
+	require.Equal(t, s.totalMoves, uint(m.stepDuration.Value().Count))
+	require.Equal(t, counts[EventMoved]+counts[EventDeparted], m.moves.Value())
+	require.Equal(t, counts[EventFought]+counts[EventRoadFight], m.fights.Value())
+	require.Equal(t, counts[EventDestroyed], m.citiesDestroyed.Value())
+	require.Equal(t, 2*counts[EventFought]+2*counts[EventRoadFight]+counts[EventStranded], m.aliensTrapped.Value())
+	require.NotZero(t, m.fights.Value())
+
+	require.Equal(t, uint64(1), m.mapLoads.Value())
+	require.Equal(t, uint64(1), m.mapLoadDuration.Value().Count)
+	require.Equal(t, float64(625), m.mapCities.Value())
+	require.NotZero(t, m.mapRoads.Value())
+
+	// engines sharing a registry add up
+	other := NewEngine(0, 0, io.MultiReader(), io.Discard, WithMetrics(NewMetrics(registry)))
+	require.NoError(t, other.LoadEngine(context.Background()))
+	require.Equal(t, uint64(2), m.mapLoads.Value())
+	require.Zero(t, m.mapCities.Value())
+}
@@ -27,6 +27,12 @@ func (w *WorldMock) GetAliveCities(ctx context.Context) ([]*types.City, error) {
 	return args.Get(0).([]*types.City), args.Error(1)
 }
 
+// GetCities retrieves the list of all cities, destroyed ones included
+func (w *WorldMock) GetCities(ctx context.Context) ([]*types.City, error) {
+	args := w.Called(ctx)
+	return args.Get(0).([]*types.City), args.Error(1)
+}
+
 // AddCity adds a city
 func (w *WorldMock) AddCity(ctx context.Context, cityName string) (*types.City, error) {
 	args := w.Called(ctx, cityName)
@@ -45,6 +51,30 @@ func (w *WorldMock) AddLink(ctx context.Context, cityFrom, cityTo *types.City, d
 	return args.Error(0)
 }
 
+// AddWeightedLink adds a link from a city to another city given a direction, travelled in a number of steps
+func (w *WorldMock) AddWeightedLink(ctx context.Context, cityFrom, cityTo *types.City, direction types.Direction, length uint) error {
+	args := w.Called(ctx, cityFrom, cityTo, direction, length)
+	return args.Error(0)
+}
+
+// AddRoad adds a one-way or two-way road between two cities
+func (w *WorldMock) AddRoad(ctx context.Context, road *types.Road) error {
+	args := w.Called(ctx, road)
+	return args.Error(0)
+}
+
+// GetRoads retrieves the list of roads between non destroyed cities
+func (w *WorldMock) GetRoads(ctx context.Context) ([]*types.Road, error) {
+	args := w.Called(ctx)
+	return args.Get(0).([]*types.Road), args.Error(1)
+}
+
+// GetCityRoads retrieves the list of roads leading into or out of a city
+func (w *WorldMock) GetCityRoads(ctx context.Context, city *types.City) ([]*types.Road, error) {
+	args := w.Called(ctx, city)
+	return args.Get(0).([]*types.Road), args.Error(1)
+}
+
 // GetAlien retrieves an alien
 func (w *WorldMock) GetAlien(ctx context.Context, alienID int) (*types.Alien, error) {
 	args := w.Called(ctx, alienID)
@@ -87,6 +117,24 @@ func (w *WorldMock) GetUntrappedAliens(ctx context.Context) ([]*types.Alien, err
 	return args.Get(0).([]*types.Alien), args.Error(1)
 }
 
+// SendAlien sends an alien from its city on the road leading in a given direction
+func (w *WorldMock) SendAlien(ctx context.Context, alien *types.Alien, direction types.Direction) error {
+	args := w.Called(ctx, alien, direction)
+	return args.Error(0)
+}
+
+// TravelAlien moves an alien one step further on its road, returns true once it reached its destination
+func (w *WorldMock) TravelAlien(ctx context.Context, alien *types.Alien) (bool, error) {
+	args := w.Called(ctx, alien)
+	return args.Bool(0), args.Error(1)
+}
+
+// GetAliensInTransit retrieves the list of untrapped aliens travelling on a road
+func (w *WorldMock) GetAliensInTransit(ctx context.Context) ([]*types.Alien, error) {
+	args := w.Called(ctx)
+	return args.Get(0).([]*types.Alien), args.Error(1)
+}
+
 // EngineMock mocks a Simulator
 type EngineMock struct {
 	mock.Mock
@@ -106,6 +154,12 @@ func (s *EngineMock) HasNextMove(ctx context.Context) (bool, error) {
 	return args.Bool(0), args.Error(1)
 }
 
+// TerminationReason computes why the simulation is over
+func (s *EngineMock) TerminationReason(ctx context.Context) (TerminationReason, error) {
+	args := s.Called(ctx)
+	return args.Get(0).(TerminationReason), args.Error(1)
+}
+
 // DoNextMove simulates the next step of the simulation
 func (s *EngineMock) DoNextMove(ctx context.Context) error {
 	args := s.Called(ctx)
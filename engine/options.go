@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"math/rand"
+
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+)
+
+// Option configures an EngineImpl
+type Option func(*EngineImpl)
+
+// WithAutoReverseRoads creates the reverse road of every road listed on only one side of the map
+func WithAutoReverseRoads(enabled bool) Option {
+	return func(s *EngineImpl) {
+		s.autoReverseRoads = enabled
+	}
+}
+
+// WithDirectionProfile sets the directions the map may use, classic north/east/south/west by default
+func WithDirectionProfile(directions *types.DirectionProfile) Option {
+	return func(s *EngineImpl) {
+		if directions != nil {
+			s.directions = directions
+		}
+	}
+}
+
+// WithRoadFights makes aliens crossing each other on a road fight, on top of fights in cities
+func WithRoadFights(enabled bool) Option {
+	return func(s *EngineImpl) {
+		s.roadFights = enabled
+	}
+}
+
+// WithPositionsReport outputs the city every untrapped alien stands in once the simulation is over
+func WithPositionsReport(enabled bool) Option {
+	return func(s *EngineImpl) {
+		s.reportPositions = enabled
+	}
+}
+
+// WithFragmentationReport reports how the destruction split the map once the simulation is over
+func WithFragmentationReport(enabled bool) Option {
+	return func(s *EngineImpl) {
+		s.reportFragmentation = enabled
+	}
+}
+
+// WithPlacement lands the aliens with the given IDs in the given cities, the other aliens landing in random cities
+func WithPlacement(placement map[int]string) Option {
+	return func(s *EngineImpl) {
+		s.placement = placement
+	}
+}
+
+// WithMapFormat sets the format of the map, detected from its content by default
+func WithMapFormat(format mapfile.Format) Option {
+	return func(s *EngineImpl) {
+		s.mapFormat = format
+	}
+}
+
+// WithMapReader loads the map from a reader instead of the input, e.g. to merge several maps;
+// the streaming and parallel loaders, which read classic input, are not used
+func WithMapReader(r mapfile.MapReader) Option {
+	return func(s *EngineImpl) {
+		s.mapReader = r
+	}
+}
+
+// WithStreamingLoader loads classic maps line by line with a StreamLoader, for maps too large to be held in memory
+func WithStreamingLoader(opts ...LoaderOption) Option {
+	return func(s *EngineImpl) {
+		s.streaming = true
+		s.loaderOptions = opts
+	}
+}
+
+// WithParallelLoader parses classic maps concurrently with a ParallelLoader, the streaming loader takes precedence
+func WithParallelLoader(opts ...LoaderOption) Option {
+	return func(s *EngineImpl) {
+		s.parallel = true
+		s.loaderOptions = opts
+	}
+}
+
+// WithSeed makes the simulation reproducible, the same seed and map always giving the same invasion
+func WithSeed(seed int64) Option {
+	return func(s *EngineImpl) {
+		s.random = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithEventListener hands every event of the simulation to a listener, as it happens
+func WithEventListener(listener func(Event)) Option {
+	return func(s *EngineImpl) {
+		s.eventListener = listener
+	}
+}
+
+// WithMetrics reports the steps, moves, fights and map loads of the simulation to metrics
+func WithMetrics(m *Metrics) Option {
+	return func(s *EngineImpl) {
+		s.metrics = m
+	}
+}
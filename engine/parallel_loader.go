@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"alien-invasion-cc/engine/mapfile"
+)
+
+// DefaultChunkSize is the size of the chunks a ParallelLoader splits a map into
+const DefaultChunkSize = 1024 * 1024
+
+// ParallelLoader loads classic maps by parsing chunks of the map concurrently, then adding them to the world in map order
+type ParallelLoader struct {
+	loaderConfig
+
+	world World
+}
+
+// mapChunk is a part of a map starting and ending on a line boundary, with its parsed city definitions
+type mapChunk struct {
+	data []byte
+
+	// number of lines of the map before the chunk
+	skipped int64
+
+	definitions []*mapfile.CityDefinition
+
+	err error
+}
+
+// Generate New ParallelLoader
+func NewParallelLoader(world World, opts ...LoaderOption) *ParallelLoader {
+	return &ParallelLoader{
+		loaderConfig: newLoaderConfig(opts),
+		world:        world,
+	}
+}
+
+// Load loads a classic map, the resulting world and errors are the same as loading it serially
+func (l *ParallelLoader) Load(ctx context.Context, in io.Reader) error {
+	content, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	chunks := splitChunks(content, l.chunkSize)
+	l.parseChunks(ctx, chunks)
+
+	// the first error in map order wins, as when parsing serially; the directions are resolved in map order too,
+	// the map running out of free-form directions on the same road
+	directions := l.directions.NewRegistry()
+	var records []cityRecord
+	for _, chunk := range chunks {
+		if chunk.err != nil {
+			return chunk.err
+		}
+
+		for _, cityDefinition := range chunk.definitions {
+			records = append(records, newCityRecord(cityDefinition, directions))
+		}
+	}
+
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	citiesFrom, err := registerCityRecords(ctx, l.world, records)
+	if err != nil {
+		return err
+	}
+	l.report(1, records, int64(len(content)), len(citiesFrom), 0)
+
+	roads, err := addRoadRecords(ctx, l.world, records, citiesFrom)
+	if err != nil {
+		return err
+	}
+	l.report(2, records, int64(len(content)), len(citiesFrom), roads)
+
+	return nil
+}
+
+// parseChunks parses the chunks with a pool of workers
+func (l *ParallelLoader) parseChunks(ctx context.Context, chunks []mapChunk) {
+	workers := l.workers
+	if workers > len(chunks) {
+		workers = len(chunks)
+	}
+
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := contextError(ctx); err != nil {
+					chunks[i].err = err
+					continue
+				}
+				l.parseChunk(&chunks[i])
+			}
+		}()
+	}
+
+	for i := range chunks {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// parseChunk parses the city definitions of a chunk, up to the first error
+func (l *ParallelLoader) parseChunk(chunk *mapChunk) {
+	scanner := mapfile.NewLineScannerAt(bytes.NewReader(chunk.data), l.bufferSize, l.maxLineSize, chunk.skipped)
+	for scanner.Scan() {
+		cityDefinition, err := mapfile.ParseClassicLine(scanner.Text())
+		if err != nil {
+			chunk.err = err
+			return
+		}
+
+		chunk.definitions = append(chunk.definitions, cityDefinition)
+	}
+
+	chunk.err = scanner.Err()
+}
+
+// report reports the end of a pass, the loader has no progress to report within a pass
+func (l *ParallelLoader) report(pass int, records []cityRecord, bytes int64, cities int, roads int64) {
+	if l.progress == nil {
+		return
+	}
+
+	l.progress(LoadProgress{
+		Pass:   pass,
+		Lines:  int64(len(records)),
+		Bytes:  bytes,
+		Cities: cities,
+		Roads:  roads,
+		Done:   true,
+	})
+}
+
+// splitChunks splits a map into chunks of about size bytes, cut after a line feed
+func splitChunks(content []byte, size int) []mapChunk {
+	var chunks []mapChunk
+	var skipped int64
+	for start := 0; start < len(content); {
+		end := start + size
+		if end >= len(content) {
+			end = len(content)
+		} else if next := bytes.IndexByte(content[end:], '\n'); next >= 0 {
+			end += next + 1
+		} else {
+			end = len(content)
+		}
+
+		data := content[start:end]
+		chunks = append(chunks, mapChunk{
+			data:    data,
+			skipped: skipped,
+		})
+
+		skipped += int64(bytes.Count(data, []byte{'\n'}))
+		start = end
+	}
+
+	return chunks
+}
@@ -0,0 +1,226 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+// loadedSerially loads a map with loadWorld, returns the world written back and the error
+func loadedSerially(t testing.TB, content []byte, directions *types.DirectionProfile) (string, error) {
+	s := NewEngine(0, 10, bytes.NewReader(content), &bytes.Buffer{}, WithDirectionProfile(directions), WithMapFormat(mapfile.FormatClassic))
+	err := s.loadWorld(context.Background())
+	return writtenWorld(t, s.world), err
+}
+
+// loadedInParallel loads a map with a ParallelLoader, returns the world written back and the error
+func loadedInParallel(t testing.TB, content []byte, opts ...LoaderOption) (string, error) {
+	world := NewWorld()
+	err := NewParallelLoader(world, opts...).Load(context.Background(), bytes.NewReader(content))
+	return writtenWorld(t, world), err
+}
+
+// requireSameLoad checks two loads ended with the same world and the same error
+func requireSameLoad(t *testing.T, wantWorld string, wantErr error, world string, err error, content []byte) {
+	if wantErr == nil {
+		require.NoError(t, err, "map:\n%s", content)
+	} else {
+		require.Error(t, err, "map:\n%s", content)
+		require.Equal(t, wantErr.Error(), err.Error(), "map:\n%s", content)
+	}
+	require.Equal(t, wantWorld, world, "map:\n%s", content)
+}
+
+func Test_ParallelLoader_SameAsLoadWorld(t *testing.T) {
+	ctx := context.Background()
+
+	for _, path := range []string{"../test_data/test_map", "../test_data/test_map2"} {
+		t.Run(path, func(t *testing.T) {
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			serial, err := loadedSerially(t, content, types.ClassicDirections)
+			require.NoError(t, err)
+
+			for _, chunkSize := range []int{1, 64, 1024, DefaultChunkSize} {
+				parallel, err := loadedInParallel(t, content, WithChunkSize(chunkSize), WithWorkers(4))
+				require.NoError(t, err)
+				require.Equal(t, serial, parallel, "chunk size %d", chunkSize)
+			}
+
+			// through the engine
+			e := NewEngine(0, 10, bytes.NewReader(content), &bytes.Buffer{}, WithParallelLoader(WithChunkSize(128)))
+			require.NoError(t, e.loadWorld(ctx))
+			require.Equal(t, serial, writtenWorld(t, e.world))
+		})
+	}
+
+	// structured formats are loaded serially
+	in, err := os.Open("../test_data/test_map.yaml")
+	require.NoError(t, err)
+	defer func() { _ = in.Close() }()
+
+	s := NewEngine(0, 10, in, &bytes.Buffer{}, WithParallelLoader())
+	require.NoError(t, s.loadWorld(ctx))
+	cities, err := s.world.GetAliveCities(ctx)
+	require.NoError(t, err)
+	require.Len(t, cities, 5)
+}
+
+// randomMap generates a small classic map, sometimes malformed, exercising every parsing path
+func randomMap(r *rand.Rand) []byte {
+	directions := []string{"north", "east", "south", "west", "up", "North", ""}
+	separators := []string{"=", "==", ""}
+	out := &bytes.Buffer{}
+
+	// a third of the maps are malformed somewhere
+	broken := r.Intn(3) == 0
+
+	lines := r.Intn(30)
+	for i := 0; i < lines; i++ {
+		switch r.Intn(12) {
+		case 0:
+			out.WriteString("\n")
+			continue
+		case 1:
+			out.WriteString("# a comment\n")
+			continue
+		case 2:
+			out.WriteString(" ")
+		}
+
+		fmt.Fprintf(out, "City%d", r.Intn(12))
+		for j := r.Intn(3); j > 0; j-- {
+			direction := directions[r.Intn(4)]
+			if broken && r.Intn(20) == 0 {
+				direction = directions[r.Intn(len(directions))]
+			}
+
+			separator := "="
+			if broken && r.Intn(20) == 0 {
+				separator = separators[r.Intn(len(separators))]
+			}
+
+			fmt.Fprintf(out, " %s%sCity%d", direction, separator, r.Intn(12))
+			switch r.Intn(8) {
+			case 0:
+				fmt.Fprintf(out, ":%d", 1+r.Intn(3))
+			case 1:
+				if broken {
+					fmt.Fprintf(out, ":%d", r.Intn(2)-1)
+				}
+			}
+		}
+
+		if r.Intn(10) == 0 {
+			out.WriteString(strings.Repeat(" west=City1", 20))
+		}
+
+		if r.Intn(10) == 0 {
+			out.WriteString("\r")
+		}
+
+		if i < lines-1 || r.Intn(2) == 0 {
+			out.WriteString("\n")
+		}
+	}
+
+	return out.Bytes()
+}
+
+func Test_ParallelLoader_Equivalence(t *testing.T) {
+	for seed := int64(1); seed <= 500; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		content := randomMap(r)
+		opts := []LoaderOption{WithChunkSize(1 + r.Intn(64)), WithWorkers(1 + r.Intn(8))}
+
+		serial, serialErr := loadedSerially(t, content, types.ClassicDirections)
+		parallel, parallelErr := loadedInParallel(t, content, opts...)
+		requireSameLoad(t, serial, serialErr, parallel, parallelErr, content)
+
+		// lines too long are reported the same whatever the chunks
+		maxLineSize := WithMaxLineSize(64 + r.Intn(128))
+		whole, wholeErr := loadedInParallel(t, content, maxLineSize, WithChunkSize(len(content)+1), WithWorkers(1))
+		chunked, chunkedErr := loadedInParallel(t, content, append(opts, maxLineSize)...)
+		requireSameLoad(t, whole, wholeErr, chunked, chunkedErr, content)
+	}
+}
+
+func Test_ParallelLoader_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	// the first error of the map is reported, even when a later chunk fails first
+	content := []byte("City1 north=City2\nCity2 north=City3=City4\nCity3 up=City1\nCity4 north=City4\n")
+	_, err := loadedInParallel(t, content, WithChunkSize(1), WithWorkers(4))
+	require.ErrorIs(t, err, types.ERR_PARSE_CITY_DEFINITION)
+
+	content = []byte("City1 north=City2\n" + strings.Repeat("x", 200) + "\nCity2 north=City2\n")
+	_, err = loadedInParallel(t, content, WithChunkSize(1), WithMaxLineSize(100))
+	require.ErrorIs(t, err, types.ERR_LINE_TOO_LONG)
+	require.Contains(t, err.Error(), "after line 1")
+
+	// cancelled before parsing
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = NewParallelLoader(NewWorld()).Load(cancelled, bytes.NewReader(content))
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_ParallelLoader_Progress(t *testing.T) {
+	ctx := context.Background()
+
+	content := &bytes.Buffer{}
+	require.NoError(t, mapfile.WriteGrid(content, 5, 5))
+	size := int64(content.Len())
+
+	var reports []LoadProgress
+	err := NewParallelLoader(NewWorld(), WithChunkSize(100), WithProgress(func(p LoadProgress) { reports = append(reports, p) }, 10)).Load(ctx, content)
+	require.NoError(t, err)
+
+	require.Equal(t, []LoadProgress{
+		{Pass: 1, Lines: 25, Bytes: size, Cities: 25, Done: true},
+		{Pass: 2, Lines: 25, Bytes: size, Cities: 25, Roads: 80, Done: true},
+	}, reports)
+}
+
+func Test_splitChunks(t *testing.T) {
+	content := []byte("a\nbb\n\nccc\nd")
+
+	chunks := splitChunks(content, 3)
+	var joined []byte
+	var skipped []int64
+	for _, chunk := range chunks {
+		joined = append(joined, chunk.data...)
+		skipped = append(skipped, chunk.skipped)
+	}
+
+	require.Equal(t, content, joined)
+	require.Equal(t, []int64{0, 2, 4}, skipped)
+	require.Equal(t, "a\nbb\n", string(chunks[0].data))
+
+	require.Empty(t, splitChunks(nil, 3))
+	require.Len(t, splitChunks(content, len(content)), 1)
+}
+
+func Benchmark_ParallelLoader_Grid(b *testing.B) {
+	ctx := context.Background()
+	path := generatedGrid(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		in, err := os.Open(path)
+		require.NoError(b, err)
+
+		err = NewParallelLoader(NewWorld()).Load(ctx, in)
+		require.NoError(b, err)
+		_ = in.Close()
+	}
+}
@@ -0,0 +1,266 @@
+package engine
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+
+	"alien-invasion-cc/engine/types"
+)
+
+// Path Type definition, a way from a city to another following the roads leading out of the cities
+type Path struct {
+	// Cities travelled through, from the starting city to the destination both included
+	Cities []*types.City
+	// Number of steps needed to travel the path, roads longer than one step counting their length
+	Steps uint
+}
+
+// Roads retrieves the number of roads the path follows
+func (p *Path) Roads() int {
+	if len(p.Cities) == 0 {
+		return 0
+	}
+	return len(p.Cities) - 1
+}
+
+// Names retrieves the names of the cities travelled through
+func (p *Path) Names() []string {
+	names := make([]string, len(p.Cities))
+	for i, city := range p.Cities {
+		names[i] = city.Name
+	}
+	return names
+}
+
+// ShortestPath retrieves a path between two cities standing following the fewest roads, by breadth-first search
+func ShortestPath(ctx context.Context, world World, from, to string) (*Path, error) {
+	cityFrom, cityTo, err := pathEnds(ctx, world, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := map[*types.City]*types.City{cityFrom: nil}
+	for queue := []*types.City{cityFrom}; len(queue) > 0 && cityFrom != cityTo; queue = queue[1:] {
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+
+		current := queue[0]
+		for _, direction := range current.Directions() {
+			next := current.Links[direction]
+			if _, seen := previous[next]; seen || next.IsDestroyed {
+				continue
+			}
+
+			previous[next] = current
+			if next == cityTo {
+				return newPath(previous, cityTo)
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	if _, found := previous[cityTo]; !found {
+		return nil, noPath(from, to)
+	}
+	return newPath(previous, cityTo)
+}
+
+// FastestPath retrieves a path between two cities standing taking the fewest steps, by Dijkstra's algorithm over the road lengths
+func FastestPath(ctx context.Context, world World, from, to string) (*Path, error) {
+	cityFrom, cityTo, err := pathEnds(ctx, world, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := map[*types.City]*types.City{cityFrom: nil}
+	steps := map[*types.City]uint{cityFrom: 0}
+	visited := make(map[*types.City]bool)
+
+	queue := &stepQueue{}
+	heap.Push(queue, stepItem{city: cityFrom})
+	for queue.Len() > 0 {
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+
+		item := heap.Pop(queue).(stepItem)
+		current := item.city
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		if current == cityTo {
+			return newPath(previous, cityTo)
+		}
+
+		for _, direction := range current.Directions() {
+			next := current.Links[direction]
+			if visited[next] || next.IsDestroyed {
+				continue
+			}
+
+			length, err := current.GetLinkLength(direction)
+			if err != nil {
+				return nil, err
+			}
+
+			if known, found := steps[next]; found && known <= item.steps+length {
+				continue
+			}
+
+			steps[next] = item.steps + length
+			previous[next] = current
+			queue.sequence++
+			heap.Push(queue, stepItem{city: next, steps: item.steps + length, sequence: queue.sequence})
+		}
+	}
+
+	return nil, noPath(from, to)
+}
+
+// Reachable retrieves the cities standing which can be reached from a city, nearest first
+func Reachable(ctx context.Context, world World, from string) ([]*types.City, error) {
+	return Neighbourhood(ctx, world, from, -1)
+}
+
+// Neighbourhood retrieves the cities standing which can be reached from a city following at most a number of roads,
+// nearest first, every city reachable when the number is negative
+func Neighbourhood(ctx context.Context, world World, from string, hops int) ([]*types.City, error) {
+	cityFrom, err := pathEnd(ctx, world, from)
+	if err != nil {
+		return nil, err
+	}
+
+	cities := []*types.City{}
+	distances := map[*types.City]int{cityFrom: 0}
+	for queue := []*types.City{cityFrom}; len(queue) > 0; queue = queue[1:] {
+		if err := contextError(ctx); err != nil {
+			return nil, err
+		}
+
+		current := queue[0]
+		if hops >= 0 && distances[current] >= hops {
+			continue
+		}
+
+		for _, direction := range current.Directions() {
+			next := current.Links[direction]
+			if _, seen := distances[next]; seen || next.IsDestroyed {
+				continue
+			}
+
+			distances[next] = distances[current] + 1
+			cities = append(cities, next)
+			queue = append(queue, next)
+		}
+	}
+
+	return cities, nil
+}
+
+// pathEnds retrieves the two ends of a path
+func pathEnds(ctx context.Context, world World, from, to string) (*types.City, *types.City, error) {
+	cityFrom, err := pathEnd(ctx, world, from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cityTo, err := pathEnd(ctx, world, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cityFrom, cityTo, nil
+}
+
+// pathEnd retrieves a city standing by name
+func pathEnd(ctx context.Context, world World, cityName string) (*types.City, error) {
+	city, err := world.GetCity(ctx, cityName)
+	if err != nil {
+		return nil, err
+	}
+
+	if city == nil || city.IsDestroyed {
+		return nil, fmt.Errorf("%w: %s", types.ERR_UNKNOWN_CITY, cityName)
+	}
+
+	return city, nil
+}
+
+// noPath reports two cities no path leads between
+func noPath(from, to string) error {
+	return fmt.Errorf("%w: from %s to %s", types.ERR_NO_PATH, from, to)
+}
+
+// newPath walks a path back from its destination
+func newPath(previous map[*types.City]*types.City, cityTo *types.City) (*Path, error) {
+	path := &Path{}
+	for city := cityTo; city != nil; city = previous[city] {
+		path.Cities = append(path.Cities, city)
+	}
+
+	for i, j := 0, len(path.Cities)-1; i < j; i, j = i+1, j-1 {
+		path.Cities[i], path.Cities[j] = path.Cities[j], path.Cities[i]
+	}
+
+	// two roads may link the same cities, the shortest one is travelled
+	for i := 1; i < len(path.Cities); i++ {
+		var shortest uint
+		for _, direction := range path.Cities[i-1].Directions() {
+			if path.Cities[i-1].Links[direction] != path.Cities[i] {
+				continue
+			}
+
+			length, err := path.Cities[i-1].GetLinkLength(direction)
+			if err != nil {
+				return nil, err
+			}
+
+			if shortest == 0 || length < shortest {
+				shortest = length
+			}
+		}
+		path.Steps += shortest
+	}
+
+	return path, nil
+}
+
+// stepItem is a city queued with the steps needed to reach it
+type stepItem struct {
+	city *types.City
+
+	steps uint
+
+	// order the city was queued in, breaking ties between equally far cities
+	sequence int
+}
+
+// stepQueue is a priority queue of cities, nearest first
+type stepQueue struct {
+	items []stepItem
+
+	sequence int
+}
+
+func (q *stepQueue) Len() int { return len(q.items) }
+
+func (q *stepQueue) Less(i, j int) bool {
+	if q.items[i].steps != q.items[j].steps {
+		return q.items[i].steps < q.items[j].steps
+	}
+	return q.items[i].sequence < q.items[j].sequence
+}
+
+func (q *stepQueue) Swap(i, j int) { q.items[i], q.items[j] = q.items[j], q.items[i] }
+
+func (q *stepQueue) Push(x interface{}) { q.items = append(q.items, x.(stepItem)) }
+
+func (q *stepQueue) Pop() interface{} {
+	item := q.items[len(q.items)-1]
+	q.items = q.items[:len(q.items)-1]
+	return item
+}
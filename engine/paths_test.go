@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Paths(t *testing.T) {
+	ctx := context.Background()
+
+	// one-way roads, A to D directly in 5 steps or through B and C in 3 steps
+	s := NewEngine(0, 10, strings.NewReader("A east=B south=D:5\nB east=C\nC south=D\nD\nE\n"), nil)
+	require.NoError(t, s.LoadEngine(ctx))
+	world := s.World()
+
+	path, err := ShortestPath(ctx, world, "A", "D")
+	require.NoError(t, err)
+	require.Equal(t, []string{"A", "D"}, path.Names())
+	require.Equal(t, 1, path.Roads())
+	require.Equal(t, uint(5), path.Steps)
+
+	path, err = FastestPath(ctx, world, "A", "D")
+	require.NoError(t, err)
+	require.Equal(t, []string{"A", "B", "C", "D"}, path.Names())
+	require.Equal(t, 3, path.Roads())
+	require.Equal(t, uint(3), path.Steps)
+
+	// a city is reached from itself without following any road
+	path, err = ShortestPath(ctx, world, "B", "B")
+	require.NoError(t, err)
+	require.Equal(t, []string{"B"}, path.Names())
+	require.Zero(t, path.Roads())
+	require.Zero(t, path.Steps)
+
+	// roads are one-way
+	_, err = ShortestPath(ctx, world, "D", "A")
+	require.ErrorIs(t, err, types.ERR_NO_PATH)
+	_, err = FastestPath(ctx, world, "A", "E")
+	require.ErrorIs(t, err, types.ERR_NO_PATH)
+
+	_, err = ShortestPath(ctx, world, "A", "Z")
+	require.ErrorIs(t, err, types.ERR_UNKNOWN_CITY)
+	require.EqualError(t, err, "city is unknown: Z")
+
+	names := func(cities []*types.City) []string {
+		return (&Path{Cities: cities}).Names()
+	}
+
+	cities, err := Neighbourhood(ctx, world, "A", 0)
+	require.NoError(t, err)
+	require.Empty(t, cities)
+
+	cities, err = Neighbourhood(ctx, world, "A", 1)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"B", "D"}, names(cities))
+
+	cities, err = Reachable(ctx, world, "A")
+	require.NoError(t, err)
+	require.Len(t, cities, 3)
+	require.Equal(t, "C", cities[2].Name)
+
+	cities, err = Reachable(ctx, world, "D")
+	require.NoError(t, err)
+	require.Empty(t, cities)
+
+	// the live world is searched: the way through B is gone once B is destroyed
+	cityB, err := world.GetCity(ctx, "B")
+	require.NoError(t, err)
+	require.NoError(t, world.DestroyCity(ctx, cityB))
+
+	path, err = FastestPath(ctx, world, "A", "D")
+	require.NoError(t, err)
+	require.Equal(t, []string{"A", "D"}, path.Names())
+	require.Equal(t, uint(5), path.Steps)
+
+	cities, err = Reachable(ctx, world, "A")
+	require.NoError(t, err)
+	require.Equal(t, []string{"D"}, names(cities))
+
+	_, err = ShortestPath(ctx, world, "B", "D")
+	require.ErrorIs(t, err, types.ERR_UNKNOWN_CITY)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = FastestPath(cancelled, world, "A", "D")
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"context"
+)
+
+// CityState Type definition, a city in a Result
+type CityState struct {
+	// City name
+	Name string `json:"name"`
+	// Flag whether the city was destroyed
+	Destroyed bool `json:"destroyed,omitempty"`
+	// Cities the roads out of the city lead to, by direction
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// AlienState Type definition, an alien in a Result
+type AlienState struct {
+	// Alien ID
+	ID int `json:"id"`
+	// City the alien resides in, or died in
+	City string `json:"city,omitempty"`
+	// Flag whether the alien is trapped
+	Trapped bool `json:"trapped,omitempty"`
+	// Road the alien is travelling on
+	Road string `json:"road,omitempty"`
+	// City the alien is travelling to
+	Destination string `json:"destination,omitempty"`
+	// Steps left before the alien reaches its destination
+	StepsLeft uint `json:"steps_left,omitempty"`
+}
+
+// Result Type definition, a snapshot of a simulation, its outcome once over
+type Result struct {
+	// Steps simulated so far
+	Steps uint `json:"steps"`
+	// Maximum number of steps
+	MaxMoves uint `json:"max_moves"`
+	// Flag whether the simulation is over
+	Finished bool `json:"finished"`
+	// Reason the simulation is over for, empty while it goes on
+	Termination TerminationReason `json:"termination,omitempty"`
+	// Number of cities still standing
+	RemainingCities int `json:"remaining_cities"`
+	// Number of destroyed cities
+	DestroyedCities int `json:"destroyed_cities"`
+	// Every city, in map order
+	Cities []CityState `json:"cities"`
+	// Every alien, by ID
+	Aliens []AlienState `json:"aliens"`
+	// How the destruction split the map, once over and when reported
+	Fragmentation *Fragmentation `json:"fragmentation,omitempty"`
+}
+
+// Result retrieves a snapshot of the simulation
+func (s *EngineImpl) Result(ctx context.Context) (*Result, error) {
+
+	// a finalized simulation keeps the reason it was finalized for
+	reason := s.termination
+	if reason == TerminationNone {
+		var err error
+		reason, err = s.TerminationReason(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &Result{
+		Steps:       s.totalMoves,
+		MaxMoves:    s.maxMoves,
+		Finished:    reason != TerminationNone,
+		Termination: reason,
+		Cities:      []CityState{},
+		Aliens:      []AlienState{},
+	}
+
+	cities, err := s.world.GetCities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, city := range cities {
+		state := CityState{
+			Name:      city.Name,
+			Destroyed: city.IsDestroyed,
+		}
+
+		if city.IsDestroyed {
+			result.DestroyedCities++
+		} else {
+			result.RemainingCities++
+		}
+
+		for _, direction := range city.Directions() {
+			if state.Links == nil {
+				state.Links = make(map[string]string)
+			}
+			state.Links[direction.String()] = city.Links[direction].Name
+		}
+
+		result.Cities = append(result.Cities, state)
+	}
+
+	for alienID := 1; alienID <= int(s.numAliens); alienID++ {
+		alien, err := s.world.GetAlien(ctx, alienID)
+		if err != nil {
+			return nil, err
+		}
+
+		if alien == nil {
+			continue
+		}
+
+		state := AlienState{
+			ID:        alien.AlienID,
+			Trapped:   alien.IsTrapped,
+			StepsLeft: alien.StepsLeft,
+		}
+
+		if alien.City != nil {
+			state.City = alien.City.Name
+		}
+
+		if alien.IsInTransit() {
+			state.Road = alien.Road.String()
+			state.Destination = alien.Destination.Name
+		}
+
+		result.Aliens = append(result.Aliens, state)
+	}
+
+	if result.Finished {
+		result.Fragmentation, err = s.Fragmentation(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
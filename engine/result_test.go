@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Engine_Result(t *testing.T) {
+	ctx := context.Background()
+
+	input := "City1 north=City2 east=City3:2\nCity2 south=City1\nCity3 west=City1:2\n"
+	s := NewEngine(2, 10, strings.NewReader(input), &bytes.Buffer{}, WithSeed(1))
+	require.NoError(t, s.LoadEngine(ctx))
+
+	result, err := s.Result(ctx)
+	require.NoError(t, err)
+	require.False(t, result.Finished)
+	require.Equal(t, uint(0), result.Steps)
+	require.Equal(t, uint(10), result.MaxMoves)
+	require.Equal(t, []CityState{
+		{Name: "City1", Links: map[string]string{"north": "City2", "east": "City3"}},
+		{Name: "City2", Links: map[string]string{"south": "City1"}},
+		{Name: "City3", Links: map[string]string{"west": "City1"}},
+	}, result.Cities[:3])
+	require.Len(t, result.Aliens, 2)
+
+	for {
+		hasNextMove, err := s.HasNextMove(ctx)
+		require.NoError(t, err)
+		if !hasNextMove {
+			break
+		}
+		require.NoError(t, s.DoNextMove(ctx))
+	}
+
+	result, err = s.Result(ctx)
+	require.NoError(t, err)
+	require.True(t, result.Finished)
+	require.Equal(t, 3, result.RemainingCities+result.DestroyedCities)
+
+	for _, city := range result.Cities {
+		if city.Destroyed {
+			require.Empty(t, city.Links)
+		}
+	}
+
+	for _, alien := range result.Aliens {
+		if alien.Road != "" {
+			require.NotEmpty(t, alien.Destination)
+			require.Empty(t, alien.City)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package engine
+
+import (
+	"context"
+
+	"alien-invasion-cc/engine/types"
+)
+
+// TerminationReason names why a simulation is over
+type TerminationReason string
+
+const (
+	// TerminationNone the simulation is not over
+	TerminationNone TerminationReason = ""
+	// TerminationMaxMoves the maximum number of moves was reached
+	TerminationMaxMoves TerminationReason = "max_moves"
+	// TerminationAllTrapped every alien is trapped
+	TerminationAllTrapped TerminationReason = "all_trapped"
+	// TerminationAllDestroyed every city is destroyed
+	TerminationAllDestroyed TerminationReason = "all_destroyed"
+	// TerminationStalemate every alien left is in a city with no road out, no progress is possible
+	TerminationStalemate TerminationReason = "stalemate"
+	// TerminationNoFights no two aliens left can ever meet, every one being alone in its part of the map
+	TerminationNoFights TerminationReason = "no_fights"
+	// TerminationStopped the simulation was finalized before its end
+	TerminationStopped TerminationReason = "stopped"
+	// TerminationCancelled the simulation was cancelled through its context
+	TerminationCancelled TerminationReason = "cancelled"
+)
+
+// String describes the termination reason
+func (r TerminationReason) String() string {
+	switch r {
+	case TerminationNone:
+		return "not over"
+	case TerminationMaxMoves:
+		return "maximum number of moves reached"
+	case TerminationAllTrapped:
+		return "every alien is trapped"
+	case TerminationAllDestroyed:
+		return "every city is destroyed"
+	case TerminationStalemate:
+		return "stalemate, no alien can move any more"
+	case TerminationNoFights:
+		return "no further fights possible"
+	case TerminationStopped:
+		return "stopped before the end"
+	case TerminationCancelled:
+		return "cancelled"
+	}
+
+	return string(r)
+}
+
+// TerminationReason computes why the simulation is over, TerminationNone while a next step exists
+func (s *EngineImpl) TerminationReason(ctx context.Context) (TerminationReason, error) {
+
+	if s.totalMoves >= s.maxMoves {
+		return TerminationMaxMoves, nil
+	}
+
+	untrappedAliens, err := s.world.GetUntrappedAliens(ctx)
+	if err != nil {
+		return TerminationNone, err
+	}
+
+	if len(untrappedAliens) == 0 {
+		return TerminationAllTrapped, nil
+	}
+
+	aliveCities, err := s.world.GetAliveCities(ctx)
+	if err != nil {
+		return TerminationNone, err
+	}
+
+	if len(aliveCities) == 0 {
+		return TerminationAllDestroyed, nil
+	}
+
+	if isStalemate(untrappedAliens) {
+		return TerminationStalemate, nil
+	}
+
+	if !s.fightsPossible(aliveCities, untrappedAliens) {
+		return TerminationNoFights, nil
+	}
+
+	return TerminationNone, nil
+}
+
+// isStalemate checks if every untrapped alien stands in a city with no road out
+func isStalemate(untrappedAliens []*types.Alien) bool {
+	for _, alien := range untrappedAliens {
+		if alien.IsInTransit() || alien.City == nil || len(alien.City.Links) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fightsPossible checks if two untrapped aliens may still meet, standing or heading in the same connected part of the map;
+// no fight destroying any more city, the map stays as it is from then on
+func (s *EngineImpl) fightsPossible(aliveCities []*types.City, untrappedAliens []*types.Alien) bool {
+	if len(untrappedAliens) < 2 {
+		return false
+	}
+
+	components := s.componentsOf(aliveCities)
+	occupied := make(map[int]bool, len(untrappedAliens))
+	for _, alien := range untrappedAliens {
+		city := alien.City
+		if alien.IsInTransit() {
+			city = alien.Destination
+		}
+
+		// an alien yet to land may end up anywhere
+		if city == nil {
+			return true
+		}
+
+		// an alien heading to a destroyed city gets stranded
+		component, found := components[city]
+		if !found {
+			continue
+		}
+
+		if occupied[component] {
+			return true
+		}
+		occupied[component] = true
+	}
+
+	return false
+}
+
+// componentsOf retrieves the connected component of every alive city, roads being followed both ways;
+// cities only ever get destroyed, so the components are computed again only once the number of alive cities changed
+func (s *EngineImpl) componentsOf(aliveCities []*types.City) map[*types.City]int {
+	if s.components != nil && s.componentCities == len(aliveCities) {
+		return s.components
+	}
+
+	s.components, s.componentCities = connectedComponents(aliveCities), len(aliveCities)
+	return s.components
+}
+
+// connectedComponents retrieves the connected component of every city, roads being followed both ways,
+// a component being identified by the index of one of its cities
+func connectedComponents(cities []*types.City) map[*types.City]int {
+	index := make(map[*types.City]int, len(cities))
+	for i, city := range cities {
+		index[city] = i
+	}
+
+	parents := make([]int, len(cities))
+	for i := range parents {
+		parents[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		for parents[i] != i {
+			parents[i] = parents[parents[i]]
+			i = parents[i]
+		}
+		return i
+	}
+
+	for i, city := range cities {
+		for _, to := range city.Links {
+			j, found := index[to]
+			if !found {
+				continue
+			}
+			parents[find(i)] = find(j)
+		}
+	}
+
+	components := make(map[*types.City]int, len(cities))
+	for i, city := range cities {
+		components[city] = find(i)
+	}
+
+	return components
+}
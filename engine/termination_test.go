@@ -0,0 +1,207 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"alien-invasion-cc/engine/types"
+)
+
+// circuit is a one-way circuit two aliens landing apart with the seed 1 chase each other around forever
+const circuit = "A east=B\nB east=C\nC east=D\nD east=A\n"
+
+func Test_Engine_TerminationReason(t *testing.T) {
+	tests := []struct {
+		name, input         string
+		numAliens, maxMoves uint
+		wantSteps           uint
+		wantReason          TerminationReason
+		wantReport          string
+	}{
+		{
+			name:       "Maximum number of moves",
+			input:      circuit,
+			numAliens:  2,
+			maxMoves:   5,
+			wantSteps:  5,
+			wantReason: TerminationMaxMoves,
+			wantReport: "Termination: maximum number of moves reached\n",
+		},
+		{
+			name:       "Every alien trapped",
+			input:      "A\n",
+			numAliens:  2,
+			maxMoves:   5,
+			wantSteps:  0,
+			wantReason: TerminationAllTrapped,
+			wantReport: "Termination: every alien is trapped\n",
+		},
+		{
+			name:       "No further fights for a single alien",
+			input:      "A east=B\nB west=A\n",
+			numAliens:  1,
+			maxMoves:   10000,
+			wantSteps:  0,
+			wantReason: TerminationNoFights,
+			wantReport: "Termination: no further fights possible\n",
+		},
+		{
+			name:       "Stalemate when landing",
+			input:      "A\nB\nC\n",
+			numAliens:  2,
+			maxMoves:   10000,
+			wantSteps:  0,
+			wantReason: TerminationStalemate,
+			wantReport: "Termination: stalemate, no alien can move any more\n",
+		},
+		{
+			name:       "Stalemate in a dead end",
+			input:      "A east=B\nB\n",
+			numAliens:  1,
+			maxMoves:   10000,
+			wantSteps:  1,
+			wantReason: TerminationStalemate,
+			wantReport: "Termination: stalemate, no alien can move any more\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			out := &bytes.Buffer{}
+
+			s := NewEngine(tt.numAliens, tt.maxMoves, strings.NewReader(tt.input), out, WithSeed(1))
+			require.NoError(t, s.Run(ctx))
+			require.Contains(t, out.String(), tt.wantReport)
+
+			reason, err := s.TerminationReason(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantReason, reason)
+
+			result, err := s.Result(ctx)
+			require.NoError(t, err)
+			require.LessOrEqual(t, result.Steps, tt.wantSteps)
+			require.True(t, result.Finished)
+			require.Equal(t, tt.wantReason, result.Termination)
+		})
+	}
+
+	t.Run("Every city destroyed", func(t *testing.T) {
+		ctx := context.Background()
+
+		// an alien yet to land, with no city left to land in
+		world := NewWorld()
+		city, err := world.AddCity(ctx, "A")
+		require.NoError(t, err)
+		_, err = world.AddAlien(ctx, 1)
+		require.NoError(t, err)
+		require.NoError(t, world.DestroyCity(ctx, city))
+
+		s := &EngineImpl{world: world, out: &bytes.Buffer{}, maxMoves: 10}
+		reason, err := s.TerminationReason(ctx)
+		require.NoError(t, err)
+		require.Equal(t, TerminationAllDestroyed, reason)
+	})
+
+	t.Run("Not over", func(t *testing.T) {
+		ctx := context.Background()
+		out := &bytes.Buffer{}
+
+		s := NewEngine(2, 5, strings.NewReader(circuit), out, WithSeed(1))
+		require.NoError(t, s.LoadEngine(ctx))
+
+		reason, err := s.TerminationReason(ctx)
+		require.NoError(t, err)
+		require.Equal(t, TerminationNone, reason)
+		require.Equal(t, "not over", reason.String())
+
+		// finalized before the end
+		require.NoError(t, s.Finalize(ctx))
+		require.Contains(t, out.String(), "Termination: stopped before the end\n")
+
+		result, err := s.Result(ctx)
+		require.NoError(t, err)
+		require.True(t, result.Finished)
+		require.Equal(t, TerminationStopped, result.Termination)
+	})
+}
+
+func Test_Engine_PositionsReport(t *testing.T) {
+	ctx := context.Background()
+	out := &bytes.Buffer{}
+
+	// two aliens apart for good: the run stops at once, reporting where they stand
+	s := NewEngine(2, 10000, strings.NewReader("A east=B\nB west=A\nC east=D\nD west=C\n"), out, WithSeed(1), WithPositionsReport(true))
+	require.NoError(t, s.Run(ctx))
+
+	result, err := s.Result(ctx)
+	require.NoError(t, err)
+	require.Equal(t, TerminationNoFights, result.Termination)
+	require.Zero(t, result.Steps)
+
+	report := out.String()
+	require.Contains(t, report, "Termination: no further fights possible\n")
+	require.Contains(t, report, "\nAliens Standing: 2\n\n")
+	for _, alien := range result.Aliens {
+		require.Contains(t, report, fmt.Sprintf("Alien #%d in %s\n", alien.ID, alien.City))
+	}
+}
+
+func Test_Engine_fightsPossible(t *testing.T) {
+	a, b, c, d, e := types.NewCity("A"), types.NewCity("B"), types.NewCity("C"), types.NewCity("D"), types.NewCity("E")
+
+	// A -> B <- C, D -> E: two parts, the roads being followed both ways
+	require.NoError(t, a.SetCityLink(b, types.East))
+	require.NoError(t, c.SetCityLink(b, types.West))
+	require.NoError(t, d.SetCityLink(e, types.East))
+	alive := []*types.City{a, b, c, d, e}
+
+	standing := func(id int, city *types.City) *types.Alien {
+		alien := types.NewAlien(id)
+		alien.City = city
+		return alien
+	}
+	heading := func(id int, from, to *types.City) *types.Alien {
+		alien := standing(id, from)
+		alien.Road = types.NewRoad(from, to, types.East, false)
+		alien.Destination = to
+		return alien
+	}
+
+	tests := []struct {
+		name   string
+		aliens []*types.Alien
+		want   bool
+	}{
+		{"No alien", nil, false},
+		{"Single alien", []*types.Alien{standing(1, a)}, false},
+		{"Same part, against the roads", []*types.Alien{standing(1, a), standing(2, c)}, true},
+		{"Different parts", []*types.Alien{standing(1, a), standing(2, d)}, false},
+		{"Heading into the part of another", []*types.Alien{standing(1, d), heading(2, a, e)}, true},
+		{"Yet to land", []*types.Alien{standing(1, a), types.NewAlien(2)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &EngineImpl{}
+			require.Equal(t, tt.want, s.fightsPossible(alive, tt.aliens))
+		})
+	}
+
+	// B destroyed splits A from C, the components are computed again
+	s := &EngineImpl{}
+	aliens := []*types.Alien{standing(1, a), standing(2, c)}
+	require.True(t, s.fightsPossible(alive, aliens))
+
+	require.NoError(t, a.RemoveCityLink(b))
+	require.NoError(t, c.RemoveCityLink(b))
+	require.False(t, s.fightsPossible([]*types.City{a, c, d, e}, aliens))
+
+	// an alien heading to a destroyed city gets stranded
+	require.False(t, s.fightsPossible([]*types.City{a, c, d, e}, []*types.Alien{standing(1, a), heading(2, c, b)}))
+}
@@ -13,6 +13,17 @@ type Alien struct {
 	City *City
 	//Flag whether Alien is trapped
 	IsTrapped bool
+	// Road the alien is travelling on, nil when it resides in a city
+	Road *Road
+	// City the alien is travelling to
+	Destination *City
+	// Steps left before the alien reaches its destination
+	StepsLeft uint
+}
+
+// IsInTransit checks if the alien is travelling on a road
+func (a *Alien) IsInTransit() bool {
+	return a.Road != nil
 }
 
 // Generate New Alien
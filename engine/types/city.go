@@ -9,104 +9,159 @@ import (
 
 type City struct {
 	Name string
-	North, East, South, West *City
+	// Cities reachable from this city, by direction
+	Links map[Direction]*City
+	// Length of the roads leading out of this city, by direction, when longer than one step
+	Lengths map[Direction]uint
+	// Free-form attributes of the city, from structured map formats
+	Attributes map[string]string
+	// Optional position of the city, from structured map formats
+	Coordinates *Coordinates
+	// Flag whether the city was destroyed
+	IsDestroyed bool
+}
+
+// Coordinates Type definition
+type Coordinates struct {
+	X float64 `json:"x" yaml:"x"`
+	Y float64 `json:"y" yaml:"y"`
 }
 
 // City constructor
 func NewCity(Name string) *City {
 	return &City{
 		Name: Name,
+		Links: make(map[Direction]*City),
+		Lengths: make(map[Direction]uint),
 	}
 }
 
 // GetCityLink retrieves the destination city given a direction
 func (c *City) GetCityLink(direction Direction) (*City, error) {
 
-	switch direction {
-	case North:
-		return c.North, nil
-	case East:
-		return c.East, nil
-	case South:
-		return c.South, nil
-	case West:
-		return c.West, nil
-	default:
+	if !direction.IsValid() {
 		var city *City
 		return city, ERR_UNKNOWN_DIRECTION
 	}
+
+	return c.Links[direction], nil
 }
 
 
-// SetCityLink sets destination city to given direction
+// SetCityLink sets destination city to given direction, a nil city removes the link
 func (c *City) SetCityLink(city *City, direction Direction) error {
-	switch direction {
-	case North:
-		c.North = city
-	case East:
-		c.East = city
-	case South:
-		c.South = city
-	case West:
-		c.West = city
-	default:
+
+	if !direction.IsValid() {
 		return ERR_UNKNOWN_DIRECTION
 	}
 
+	if city == nil {
+		delete(c.Links, direction)
+		delete(c.Lengths, direction)
+		return nil
+	}
+
+	if c.Links == nil {
+		c.Links = make(map[Direction]*City)
+	}
+
+	c.Links[direction] = city
 	return nil
 }
 
 
-// RemoveCityLink removes the destination city in any direction if it exists
+// RemoveCityLink removes the destination city in every direction it is linked to
 func (c *City) RemoveCityLink(city *City) error {
-	switch {
-	case c.North == city:
-		c.North = nil
-	case c.South == city:
-		c.South = nil
-	case c.East == city:
-		c.East = nil
-	case c.West == city:
-		c.West = nil
-	default:
+	found := false
+	for direction, cityTo := range c.Links {
+		if cityTo == city {
+			delete(c.Links, direction)
+			delete(c.Lengths, direction)
+			found = true
+		}
+	}
+
+	if !found {
 		return ERR_UNKNOWN_CITY
 	}
 
 	return nil
 }
 
-// GetAvailableLinks retrieves the available links from this city
-func (c *City) GetAvailableLinks() map[Direction]*City {
-	links := make(map[Direction]*City)
-	if c.North != nil {
-		links[North] = c.North
+// GetLinkLength retrieves the number of steps needed to travel in a given direction
+func (c *City) GetLinkLength(direction Direction) (uint, error) {
+
+	if !direction.IsValid() {
+		return 0, ERR_UNKNOWN_DIRECTION
+	}
+
+	if _, found := c.Links[direction]; !found {
+		return 0, ERR_MISSING_CITY
+	}
+
+	if length, found := c.Lengths[direction]; found {
+		return length, nil
+	}
+
+	return 1, nil
+}
+
+// SetLinkLength sets the number of steps needed to travel in a given direction
+func (c *City) SetLinkLength(direction Direction, length uint) error {
+
+	if !direction.IsValid() {
+		return ERR_UNKNOWN_DIRECTION
+	}
+
+	if _, found := c.Links[direction]; !found {
+		return ERR_MISSING_CITY
 	}
-	if c.South != nil {
-		links[South] = c.South
+
+	if length == 0 {
+		return ERR_INVALID_ROAD_LENGTH
 	}
-	if c.West != nil {
-		links[West] = c.West
+
+	if length == 1 {
+		delete(c.Lengths, direction)
+		return nil
 	}
-	if c.East != nil {
-		links[East] = c.East
+
+	if c.Lengths == nil {
+		c.Lengths = make(map[Direction]uint)
+	}
+
+	c.Lengths[direction] = length
+	return nil
+}
+
+// GetAvailableLinks retrieves the available links from this city
+func (c *City) GetAvailableLinks() map[Direction]*City {
+	links := make(map[Direction]*City)
+	for direction, city := range c.Links {
+		links[direction] = city
 	}
 	return links
 }
 
+// Directions retrieves the directions leading out of this city, built-in directions first then free-form ones by name
+func (c *City) Directions() []Direction {
+	directions := make([]Direction, 0, len(c.Links))
+	for direction := range c.Links {
+		directions = append(directions, direction)
+	}
+	SortDirections(directions)
+	return directions
+}
+
 // String output of City
 func (c *City) String() string {
 	chunks := []string{c.Name}
-	if c.North != nil {
-		chunks = append(chunks, fmt.Sprintf("north=%s", c.North.Name))
-	}
-	if c.East != nil {
-		chunks = append(chunks, fmt.Sprintf("east=%s", c.East.Name))
-	}
-	if c.South != nil {
-		chunks = append(chunks, fmt.Sprintf("south=%s", c.South.Name))
-	}
-	if c.West != nil {
-		chunks = append(chunks, fmt.Sprintf("west=%s", c.West.Name))
+	for _, direction := range c.Directions() {
+		chunk := fmt.Sprintf("%s=%s", direction, c.Links[direction].Name)
+		if length, found := c.Lengths[direction]; found && length > 1 {
+			chunk = fmt.Sprintf("%s:%d", chunk, length)
+		}
+		chunks = append(chunks, chunk)
 	}
 	return strings.Join(chunks, " ")
-}
\ No newline at end of file
+}
@@ -22,10 +22,10 @@ func Test_NewCity(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.cityName, func(t *testing.T) {
 			c := NewCity(tt.cityName)
-			c.North = tt.cityNorth
-			c.East = tt.cityEast
-			c.South = tt.citySouth
-			c.West = tt.cityWest
+			require.NoError(t, c.SetCityLink(tt.cityNorth, North))
+			require.NoError(t, c.SetCityLink(tt.cityEast, East))
+			require.NoError(t, c.SetCityLink(tt.citySouth, South))
+			require.NoError(t, c.SetCityLink(tt.cityWest, West))
 			require.Equal(t, tt.want, c.String())
 		})
 	}
@@ -60,7 +60,7 @@ func Test_City_Flow(t *testing.T) {
 		},
 		{
 			name:          "UnknownDirection",
-			giveDirection: Direction(100),
+			giveDirection: Direction(""),
 			wantError:     ERR_UNKNOWN_DIRECTION,
 		},
 	}
@@ -113,4 +113,65 @@ func Test_City_Flow(t *testing.T) {
 			require.Equal(t, map[Direction]*City{}, cities)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func Test_City_RemoveCityLink_AllDirections(t *testing.T) {
+	city1 := NewCity("City1")
+	city2 := NewCity("City2")
+	city3 := NewCity("City3")
+
+	require.NoError(t, city1.SetCityLink(city2, North))
+	require.NoError(t, city1.SetCityLink(city2, West))
+	require.NoError(t, city1.SetCityLink(city3, East))
+
+	err := city1.RemoveCityLink(city2)
+	require.NoError(t, err)
+	require.Equal(t, map[Direction]*City{East: city3}, city1.GetAvailableLinks())
+
+	err = city1.RemoveCityLink(city2)
+	require.ErrorIs(t, err, ERR_UNKNOWN_CITY)
+}
+
+func Test_City_String_Directions(t *testing.T) {
+	bridge := Direction("bridge")
+
+	c := NewCity("City1")
+	require.NoError(t, c.SetCityLink(&City{Name: "CityB"}, bridge))
+	require.NoError(t, c.SetCityLink(&City{Name: "CityU"}, Up))
+	require.NoError(t, c.SetCityLink(&City{Name: "CityNE"}, NorthEast))
+	require.NoError(t, c.SetCityLink(&City{Name: "CityS"}, South))
+	require.Equal(t, "City1 south=CityS northeast=CityNE up=CityU bridge=CityB", c.String())
+}
+
+func Test_City_LinkLength(t *testing.T) {
+	city1 := NewCity("City1")
+	city2 := NewCity("City2")
+
+	_, err := city1.GetLinkLength(North)
+	require.ErrorIs(t, err, ERR_MISSING_CITY)
+
+	err = city1.SetLinkLength(North, 3)
+	require.ErrorIs(t, err, ERR_MISSING_CITY)
+
+	require.NoError(t, city1.SetCityLink(city2, North))
+
+	length, err := city1.GetLinkLength(North)
+	require.NoError(t, err)
+	require.Equal(t, uint(1), length)
+	require.Equal(t, "City1 north=City2", city1.String())
+
+	err = city1.SetLinkLength(North, 0)
+	require.ErrorIs(t, err, ERR_INVALID_ROAD_LENGTH)
+
+	require.NoError(t, city1.SetLinkLength(North, 3))
+	length, err = city1.GetLinkLength(North)
+	require.NoError(t, err)
+	require.Equal(t, uint(3), length)
+	require.Equal(t, "City1 north=City2:3", city1.String())
+
+	_, err = city1.GetLinkLength(Direction(""))
+	require.ErrorIs(t, err, ERR_UNKNOWN_DIRECTION)
+
+	require.NoError(t, city1.RemoveCityLink(city2))
+	require.Empty(t, city1.Lengths)
+}
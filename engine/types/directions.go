@@ -1,11 +1,218 @@
 package types
 
-// Direction Type definition
-type Direction int
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Direction Type definition, the name of the direction a road leads in
+type Direction string
+
+const (
+	North     Direction = "north"
+	East      Direction = "east"
+	South     Direction = "south"
+	West      Direction = "west"
+	NorthEast Direction = "northeast"
+	SouthEast Direction = "southeast"
+	SouthWest Direction = "southwest"
+	NorthWest Direction = "northwest"
+	Up        Direction = "up"
+	Down      Direction = "down"
+)
 
 const (
-	North Direction = iota + 1
-	East 
-	South
-	West
-)
\ No newline at end of file
+	// MaxFreeFormDirections bounds the number of free-form directions a map may use
+	MaxFreeFormDirections = 10000
+	// MaxDirectionNameLength bounds the length in bytes of a free-form direction name
+	MaxDirectionNameLength = 64
+)
+
+// builtinDirections lists the built-in directions in their order
+var builtinDirections = []Direction{North, East, South, West, NorthEast, SouthEast, SouthWest, NorthWest, Up, Down}
+
+// opposites holds the direction leading back from every built-in direction
+var opposites = map[Direction]Direction{
+	North:     South,
+	East:      West,
+	South:     North,
+	West:      East,
+	NorthEast: SouthWest,
+	SouthEast: NorthWest,
+	SouthWest: NorthEast,
+	NorthWest: SouthEast,
+	Up:        Down,
+	Down:      Up,
+}
+
+// builtinOrder holds the rank of every built-in direction
+var builtinOrder = func() map[Direction]int {
+	order := make(map[Direction]int, len(builtinDirections))
+	for i, direction := range builtinDirections {
+		order[direction] = i
+	}
+	return order
+}()
+
+// isValidDirectionName checks a direction name can be written in a map
+func isValidDirectionName(name string) bool {
+	return name != "" && len(name) <= MaxDirectionNameLength && !strings.ContainsAny(name, "=: \t\r\n#")
+}
+
+// isBuiltin checks if the direction is a built-in direction
+func (d Direction) isBuiltin() bool {
+	_, found := opposites[d]
+	return found
+}
+
+// IsValid checks if the direction is a built-in direction or a well-formed free-form direction
+func (d Direction) IsValid() bool {
+	return d.isBuiltin() || isValidDirectionName(string(d))
+}
+
+// Opposite retrieves the direction leading back; free-form directions lead back the same way
+func (d Direction) Opposite() (Direction, error) {
+	if opposite, found := opposites[d]; found {
+		return opposite, nil
+	}
+
+	if !d.IsValid() {
+		return d, ERR_UNKNOWN_DIRECTION
+	}
+
+	return d, nil
+}
+
+// String output of Direction
+func (d Direction) String() string {
+	if !d.IsValid() {
+		return "unknown"
+	}
+
+	return string(d)
+}
+
+// SortDirections sorts the built-in directions in their order, classic directions first, then the free-form
+// directions by name
+func SortDirections(d []Direction) {
+	sort.Slice(d, func(i, j int) bool {
+		iOrder, iBuiltin := builtinOrder[d[i]]
+		jOrder, jBuiltin := builtinOrder[d[j]]
+		if iBuiltin && jBuiltin {
+			return iOrder < jOrder
+		}
+		if iBuiltin || jBuiltin {
+			return iBuiltin
+		}
+		return d[i] < d[j]
+	})
+}
+
+// DirectionProfile defines the set of directions a map may use
+type DirectionProfile struct {
+	// Name of the profile
+	Name string
+	// Flag whether any well-formed direction name is accepted
+	FreeForm bool
+
+	directions map[string]Direction
+}
+
+var (
+	// ClassicDirections allows north, east, south and west
+	ClassicDirections = NewDirectionProfile("classic", false, North, East, South, West)
+	// CompassDirections allows the eight compass points
+	CompassDirections = NewDirectionProfile("compass", false, North, East, South, West, NorthEast, SouthEast, SouthWest, NorthWest)
+	// LayeredDirections allows the eight compass points plus up and down
+	LayeredDirections = NewDirectionProfile("layered", false, North, East, South, West, NorthEast, SouthEast, SouthWest, NorthWest, Up, Down)
+	// FreeFormDirections allows any named road
+	FreeFormDirections = NewDirectionProfile("free", true, North, East, South, West, NorthEast, SouthEast, SouthWest, NorthWest, Up, Down)
+)
+
+// DirectionProfiles lists the built-in direction profiles
+var DirectionProfiles = []*DirectionProfile{ClassicDirections, CompassDirections, LayeredDirections, FreeFormDirections}
+
+// Generate New DirectionProfile
+func NewDirectionProfile(name string, freeForm bool, allowed ...Direction) *DirectionProfile {
+	p := &DirectionProfile{
+		Name:       name,
+		FreeForm:   freeForm,
+		directions: make(map[string]Direction),
+	}
+
+	for _, direction := range allowed {
+		p.directions[direction.String()] = direction
+	}
+
+	return p
+}
+
+// GetDirectionProfile retrieves a built-in direction profile by name
+func GetDirectionProfile(name string) (*DirectionProfile, error) {
+	for _, p := range DirectionProfiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+
+	return nil, ERR_UNKNOWN_DIRECTION_PROFILE
+}
+
+// NewRegistry creates the registry of the directions of a map using the profile
+func (p *DirectionProfile) NewRegistry() *DirectionRegistry {
+	return &DirectionRegistry{
+		profile:  p,
+		freeForm: make(map[string]Direction),
+	}
+}
+
+// String output of DirectionProfile
+func (p *DirectionProfile) String() string {
+	return p.Name
+}
+
+// DirectionRegistry Type definition, the directions of a map, the free-form ones being registered while the map is
+// loaded; the registry belongs to the map, so the free-form names are released with it
+type DirectionRegistry struct {
+	profile *DirectionProfile
+
+	mu       sync.Mutex
+	freeForm map[string]Direction
+}
+
+// Parse retrieves the direction of the profile given its name, registering a free-form direction the first time
+// it is met, up to MaxFreeFormDirections
+func (r *DirectionRegistry) Parse(name string) (Direction, error) {
+	if direction, found := r.profile.directions[name]; found {
+		return direction, nil
+	}
+
+	if !r.profile.FreeForm || !isValidDirectionName(name) {
+		return Direction(""), ERR_UNKNOWN_DIRECTION
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if direction, found := r.freeForm[name]; found {
+		return direction, nil
+	}
+
+	if len(r.freeForm) >= MaxFreeFormDirections {
+		return Direction(""), ERR_TOO_MANY_DIRECTIONS
+	}
+
+	// the name may be sliced out of a whole line, the roads would keep the line alive
+	direction := Direction([]byte(name))
+	r.freeForm[name] = direction
+	return direction, nil
+}
+
+// Len retrieves the number of free-form directions registered
+func (r *DirectionRegistry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.freeForm)
+}
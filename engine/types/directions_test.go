@@ -0,0 +1,127 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Direction_Opposite(t *testing.T) {
+	bridge := Direction("bridge")
+
+	tests := []struct {
+		giveDirection, wantDirection Direction
+		wantError                    error
+	}{
+		{North, South, nil},
+		{East, West, nil},
+		{South, North, nil},
+		{West, East, nil},
+		{NorthEast, SouthWest, nil},
+		{NorthWest, SouthEast, nil},
+		{Up, Down, nil},
+		{bridge, bridge, nil},
+		{Direction("a=b"), Direction("a=b"), ERR_UNKNOWN_DIRECTION},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.giveDirection.String(), func(t *testing.T) {
+			opposite, err := tt.giveDirection.Opposite()
+			require.Equal(t, tt.wantError, err)
+			require.Equal(t, tt.wantDirection, opposite)
+		})
+	}
+}
+
+func Test_DirectionRegistry_Parse(t *testing.T) {
+	registry := FreeFormDirections.NewRegistry()
+
+	ferry, err := registry.Parse("ferry")
+	require.NoError(t, err)
+	require.True(t, ferry.IsValid())
+	require.Equal(t, "ferry", ferry.String())
+
+	again, err := registry.Parse("ferry")
+	require.NoError(t, err)
+	require.Equal(t, ferry, again)
+
+	north, err := registry.Parse("north")
+	require.NoError(t, err)
+	require.Equal(t, North, north)
+	require.Equal(t, 1, registry.Len())
+
+	for _, name := range []string{"", "a=b", "a b", "a:b", "#a", strings.Repeat("a", MaxDirectionNameLength+1)} {
+		_, err = registry.Parse(name)
+		require.ErrorIs(t, err, ERR_UNKNOWN_DIRECTION)
+	}
+
+	_, err = ClassicDirections.NewRegistry().Parse("ferry")
+	require.ErrorIs(t, err, ERR_UNKNOWN_DIRECTION)
+}
+
+func Test_DirectionRegistry_Bounded(t *testing.T) {
+	first := FreeFormDirections.NewRegistry()
+	for i := 0; i < MaxFreeFormDirections; i++ {
+		_, err := first.Parse(fmt.Sprintf("road%d", i))
+		require.NoError(t, err)
+	}
+
+	_, err := first.Parse("one-more")
+	require.ErrorIs(t, err, ERR_TOO_MANY_DIRECTIONS)
+
+	again, err := first.Parse("road0")
+	require.NoError(t, err)
+	require.Equal(t, "road0", again.String())
+
+	// the registry of another map has its own room
+	second := FreeFormDirections.NewRegistry()
+	for i := 0; i < MaxFreeFormDirections; i++ {
+		_, err := second.Parse(fmt.Sprintf("other%d", i))
+		require.NoError(t, err)
+	}
+	require.Equal(t, MaxFreeFormDirections, second.Len())
+}
+
+func Test_SortDirections(t *testing.T) {
+	// free-form directions are sorted by name, whichever was met first
+	d := []Direction{"canal", Up, "zipline", West, North}
+	SortDirections(d)
+	require.Equal(t, []Direction{North, West, Up, "canal", "zipline"}, d)
+}
+
+func Test_DirectionProfile_Parse(t *testing.T) {
+	tests := []struct {
+		profile   string
+		giveName  string
+		wantError error
+	}{
+		{"classic", "north", nil},
+		{"classic", "northeast", ERR_UNKNOWN_DIRECTION},
+		{"classic", "up", ERR_UNKNOWN_DIRECTION},
+		{"compass", "northeast", nil},
+		{"compass", "down", ERR_UNKNOWN_DIRECTION},
+		{"layered", "down", nil},
+		{"layered", "tunnel", ERR_UNKNOWN_DIRECTION},
+		{"free", "tunnel", nil},
+		{"free", "west", nil},
+		{"free", "a=b", ERR_UNKNOWN_DIRECTION},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile+"/"+tt.giveName, func(t *testing.T) {
+			p, err := GetDirectionProfile(tt.profile)
+			require.NoError(t, err)
+
+			direction, err := p.NewRegistry().Parse(tt.giveName)
+			require.Equal(t, tt.wantError, err)
+			if err == nil {
+				require.Equal(t, tt.giveName, direction.String())
+			}
+		})
+	}
+
+	_, err := GetDirectionProfile("hexagonal")
+	require.ErrorIs(t, err, ERR_UNKNOWN_DIRECTION_PROFILE)
+}
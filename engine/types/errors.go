@@ -25,8 +25,32 @@ var (
 
 	ERR_UNKNOWN_DIRECTION error = fmt.Errorf("unknown direction provided")
 	
+	ERR_UNKNOWN_DIRECTION_PROFILE error = fmt.Errorf("unknown direction profile provided")
+
+	ERR_TOO_MANY_DIRECTIONS error = fmt.Errorf("too many free-form directions in the map")
+
 	ERR_ALREADY_EXISTS_LINK error = fmt.Errorf("a link already exists between the two cities")
 
+	ERR_MISSING_ROAD error = fmt.Errorf("road is missing")
+
+	ERR_INVALID_ROAD_LENGTH error = fmt.Errorf("road length must be at least one step")
+
+	ERR_ROAD_LENGTH_MISMATCH error = fmt.Errorf("the two sides of a road have different lengths")
+
+	ERR_NO_PATH error = fmt.Errorf("no path leads between the cities")
+
+	ERR_ALIEN_NOT_IN_CITY error = fmt.Errorf("alien is not in a city")
+
+	ERR_UNKNOWN_MAP_FORMAT error = fmt.Errorf("unknown map format")
+
+	ERR_UNREPRESENTABLE_NAME error = fmt.Errorf("name can't be written in the classic map format")
+
+	ERR_LINE_TOO_LONG error = fmt.Errorf("map line is longer than the maximum line size")
+
+	ERR_TRAPPED_ALIEN error = fmt.Errorf("alien is trapped")
+
+	ERR_CITY_OCCUPIED error = fmt.Errorf("city is occupied by another alien")
+
 	ERR_RANDOM_OUT_OF_BOUNDS  error = fmt.Errorf("random input out of bounds")
 
 	ERR_CONTEXT_CANCELLED  error = fmt.Errorf("the context was cancelled")
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"fmt"
+)
+
+// Road Type definition
+type Road struct {
+	// City where the road starts
+	From *City
+	// City where the road leads to
+	To *City
+	// Direction of the road seen from the starting city
+	Direction Direction
+	// Flag whether the road can be travelled back from the destination city
+	TwoWay bool
+	// Number of steps needed to travel the road
+	Length uint
+}
+
+// Generate New Road
+func NewRoad(from, to *City, direction Direction, twoWay bool) *Road {
+	return &Road{
+		From:      from,
+		To:        to,
+		Direction: direction,
+		TwoWay:    twoWay,
+		Length:    1,
+	}
+}
+
+// Touches checks if the road starts or ends at a city
+func (r *Road) Touches(city *City) bool {
+	return r.From == city || r.To == city
+}
+
+// Leads checks if the road can be travelled from a city in a given direction
+func (r *Road) Leads(city *City, direction Direction) bool {
+	if r.From == city && r.Direction == direction {
+		return true
+	}
+
+	if !r.TwoWay || r.To != city {
+		return false
+	}
+
+	opposite, err := r.Direction.Opposite()
+	return err == nil && opposite == direction
+}
+
+// Other retrieves the city at the other end of the road
+func (r *Road) Other(city *City) *City {
+	if r.From == city {
+		return r.To
+	}
+	return r.From
+}
+
+// String output of Road
+func (r *Road) String() string {
+	arrow := "->"
+	if r.TwoWay {
+		arrow = "<->"
+	}
+	if r.Length > 1 {
+		return fmt.Sprintf("%s %s %s (%s, %d steps)", r.From.Name, arrow, r.To.Name, r.Direction, r.Length)
+	}
+	return fmt.Sprintf("%s %s %s (%s)", r.From.Name, arrow, r.To.Name, r.Direction)
+}
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewRoad(t *testing.T) {
+	city1 := NewCity("City1")
+	city2 := NewCity("City2")
+	city3 := NewCity("City3")
+
+	oneWay := NewRoad(city1, city2, North, false)
+	require.Equal(t, "City1 -> City2 (north)", oneWay.String())
+	require.True(t, oneWay.Touches(city1))
+	require.True(t, oneWay.Touches(city2))
+	require.False(t, oneWay.Touches(city3))
+	require.Equal(t, city2, oneWay.Other(city1))
+	require.Equal(t, city1, oneWay.Other(city2))
+
+	twoWay := NewRoad(city1, city3, West, true)
+	require.Equal(t, "City1 <-> City3 (west)", twoWay.String())
+}
@@ -3,7 +3,9 @@ package engine
 
 import (
 	"context"
+	"sort"
 	"alien-invasion-cc/engine/types"
+	"alien-invasion-cc/trace"
 )
 
 
@@ -15,7 +17,11 @@ type WorldImpl struct {
 
 	alienInCities map[*types.City]*types.Alien
 
-	links map[*types.City][]*types.City
+	roads map[*types.City][]*types.Road
+
+	roadList []*types.Road
+
+	cityList []*types.City
 }
 
 var _ World = (*WorldImpl)(nil)
@@ -26,14 +32,14 @@ func NewWorld() *WorldImpl {
 		cities	 			= make(map[string]*types.City)
 		aliens				= make(map[int]*types.Alien)
 		alienInCities		= make(map[*types.City]*types.Alien)
-		links	= make(map[*types.City][]*types.City) 
+		roads	= make(map[*types.City][]*types.Road)
 	)
 
 	return &WorldImpl{
 		cities: 			cities,
 		aliens:				aliens,
 		alienInCities: 		alienInCities,
-		links:	links,
+		roads:	roads,
 	}
 }
 
@@ -62,42 +68,78 @@ func (w *WorldImpl) AddCity(ctx context.Context, cityName string) (*types.City,
 
 	newCity := types.NewCity(cityName)
 	w.cities[newCity.Name] = newCity
+	w.cityList = append(w.cityList, newCity)
 
 	return newCity, nil
 }
 
-// DestroyCity remove city from world
+// DestroyCity remove city from world, along with every road leading into or out of it
 func (w *WorldImpl) DestroyCity(ctx context.Context, city *types.City) error {
 
-	if citiesFrom, found := w.links[city]; found {
-		for _, cityFrom := range citiesFrom {
-			err := cityFrom.RemoveCityLink(city)
-			if err != nil {
-				return err
-			}
+	_, span := trace.Start(ctx, "World.DestroyCity", trace.String("city", city.Name), trace.Int("roads", len(w.roads[city])))
+	defer span.End()
+
+	for _, road := range w.roads[city] {
+		err := w.unsetRoad(road)
+		if err != nil {
+			return err
 		}
+
+		other := road.Other(city)
+		w.roads[other] = removeRoads(w.roads[other], road)
+	}
+
+	if len(w.roads[city]) > 0 {
+		w.roadList = removeRoads(w.roadList, w.roads[city]...)
+	}
+
+	if w.cities[city.Name] == city {
+		delete(w.cities, city.Name)
+		city.IsDestroyed = true
 	}
 
-	delete(w.cities, city.Name)
+	// an alien still in the city is trapped in its ruins
+	if alien, found := w.alienInCities[city]; found {
+		alien.IsTrapped = true
+	}
 	delete(w.alienInCities, city)
-	delete(w.links, city)
+	delete(w.roads, city)
 	
 	return nil
 }
 
-// GetAliveCities retrieves list of non-destroyed cities
+// GetAliveCities retrieves list of non-destroyed cities, in the order they were added
 func (w *WorldImpl) GetAliveCities(ctx context.Context) ([]*types.City, error) {
 
 	var cities []*types.City
-	for _, city := range w.cities {
-		cities = append(cities, city)
+	for _, city := range w.cityList {
+		if !city.IsDestroyed {
+			cities = append(cities, city)
+		}
 	}
 
 	return cities, nil
 }
 
+// GetCities retrieves list of all cities, destroyed ones included, in the order they were added
+func (w *WorldImpl) GetCities(ctx context.Context) ([]*types.City, error) {
+
+	var cities []*types.City
+	cities = append(cities, w.cityList...)
+	return cities, nil
+}
+
 // AddLink add a link from a city to another city with direction
 func (w *WorldImpl) AddLink(ctx context.Context, cityFrom, cityTo *types.City, direction types.Direction) error {
+	return w.AddWeightedLink(ctx, cityFrom, cityTo, direction, 1)
+}
+
+// AddWeightedLink add a link from a city to another city with direction, travelled in a number of steps
+func (w *WorldImpl) AddWeightedLink(ctx context.Context, cityFrom, cityTo *types.City, direction types.Direction, length uint) error {
+
+	if length == 0 {
+		return types.ERR_INVALID_ROAD_LENGTH
+	}
 
 	if cityFrom == nil {
 		return types.ERR_MISSING_CITY
@@ -116,7 +158,7 @@ func (w *WorldImpl) AddLink(ctx context.Context, cityFrom, cityTo *types.City, d
 		return err
 	}
 
-	if cityFromFound == nil {
+	if cityFromFound == nil || cityFromFound != cityFrom {
 		return types.ERR_UNKNOWN_CITY
 	}
 
@@ -125,7 +167,7 @@ func (w *WorldImpl) AddLink(ctx context.Context, cityFrom, cityTo *types.City, d
 		return err
 	}
 
-	if cityToFound == nil {
+	if cityToFound == nil || cityToFound != cityTo {
 		return types.ERR_UNKNOWN_CITY
 	}
 
@@ -138,21 +180,190 @@ func (w *WorldImpl) AddLink(ctx context.Context, cityFrom, cityTo *types.City, d
 		return types.ERR_ALREADY_EXISTS_LINK
 	}
 
-	err = cityFrom.SetCityLink(cityTo, direction)
+	if cityToRegistered == cityTo {
+		registeredLength, err := cityFrom.GetLinkLength(direction)
+		if err != nil {
+			return err
+		}
+
+		if registeredLength != length {
+			return types.ERR_ROAD_LENGTH_MISMATCH
+		}
+		return nil
+	}
+
+	// the road was already listed from the other side: it becomes two-way
+	if reverse := w.findRoad(cityTo, cityFrom, direction); reverse != nil {
+		if reverse.Length != length {
+			return types.ERR_ROAD_LENGTH_MISMATCH
+		}
+
+		err = cityFrom.SetCityLink(cityTo, direction)
+		if err != nil {
+			return err
+		}
+
+		err = cityFrom.SetLinkLength(direction, length)
+		if err != nil {
+			return err
+		}
+
+		reverse.TwoWay = true
+		return nil
+	}
+
+	road := types.NewRoad(cityFrom, cityTo, direction, false)
+	road.Length = length
+	return w.AddRoad(ctx, road)
+}
+
+// AddRoad add a one-way or two-way road between two cities
+func (w *WorldImpl) AddRoad(ctx context.Context, road *types.Road) error {
+
+	if road == nil {
+		return types.ERR_MISSING_ROAD
+	}
+
+	if road.From == nil || road.To == nil {
+		return types.ERR_MISSING_CITY
+	}
+
+	if road.From.Name == road.To.Name {
+		return types.ERR_LINK_SAME_CITY
+	}
+
+	if road.Length == 0 {
+		return types.ERR_INVALID_ROAD_LENGTH
+	}
+
+	for _, city := range []*types.City{road.From, road.To} {
+		cityFound, err := w.GetCity(ctx, city.Name)
+		if err != nil {
+			return err
+		}
+
+		if cityFound == nil || cityFound != city {
+			return types.ERR_UNKNOWN_CITY
+		}
+	}
+
+	cityToRegistered, err := road.From.GetCityLink(road.Direction)
+	if err != nil {
+		return err
+	}
+
+	if cityToRegistered != nil {
+		return types.ERR_ALREADY_EXISTS_LINK
+	}
+
+	var opposite types.Direction
+	if road.TwoWay {
+		opposite, err = road.Direction.Opposite()
+		if err != nil {
+			return err
+		}
+
+		cityFromRegistered, err := road.To.GetCityLink(opposite)
+		if err != nil {
+			return err
+		}
+
+		if cityFromRegistered != nil {
+			return types.ERR_ALREADY_EXISTS_LINK
+		}
+	}
+
+	err = road.From.SetCityLink(road.To, road.Direction)
 	if err != nil {
 		return err
 	}
 
-	citiesFrom, found := w.links[cityTo]
-	if !found {
-		citiesFrom = make([]*types.City, 0)
+	err = road.From.SetLinkLength(road.Direction, road.Length)
+	if err != nil {
+		return err
 	}
 
-	citiesFrom = append(citiesFrom, cityFrom)
-	w.links[cityTo] = citiesFrom
+	if road.TwoWay {
+		err = road.To.SetCityLink(road.From, opposite)
+		if err != nil {
+			return err
+		}
+
+		err = road.To.SetLinkLength(opposite, road.Length)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.roads[road.From] = append(w.roads[road.From], road)
+	w.roads[road.To] = append(w.roads[road.To], road)
+	w.roadList = append(w.roadList, road)
 	return nil
 }
 
+// GetRoads retrieves the list of roads between non-destroyed cities, in the order they were added
+func (w *WorldImpl) GetRoads(ctx context.Context) ([]*types.Road, error) {
+
+	var roads []*types.Road
+	roads = append(roads, w.roadList...)
+	return roads, nil
+}
+
+// GetCityRoads retrieves the list of roads leading into or out of a city
+func (w *WorldImpl) GetCityRoads(ctx context.Context, city *types.City) ([]*types.Road, error) {
+
+	if city == nil {
+		return nil, types.ERR_MISSING_CITY
+	}
+
+	cityFound, err := w.GetCity(ctx, city.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if cityFound == nil || cityFound != city {
+		return nil, types.ERR_UNKNOWN_CITY
+	}
+
+	roads := make([]*types.Road, len(w.roads[city]))
+	copy(roads, w.roads[city])
+	return roads, nil
+}
+
+// findRoad retrieves the road travelled from cityFrom to cityTo which comes back in a given direction
+func (w *WorldImpl) findRoad(cityFrom, cityTo *types.City, backDirection types.Direction) *types.Road {
+	for _, road := range w.roads[cityFrom] {
+		if road.From != cityFrom || road.To != cityTo {
+			continue
+		}
+
+		opposite, err := road.Direction.Opposite()
+		if err == nil && opposite == backDirection {
+			return road
+		}
+	}
+	return nil
+}
+
+// unsetRoad clears the city links a road is made of
+func (w *WorldImpl) unsetRoad(road *types.Road) error {
+	err := road.From.SetCityLink(nil, road.Direction)
+	if err != nil {
+		return err
+	}
+
+	if !road.TwoWay {
+		return nil
+	}
+
+	opposite, err := road.Direction.Opposite()
+	if err != nil {
+		return err
+	}
+
+	return road.To.SetCityLink(nil, opposite)
+}
+
 // GetAlien retrieves Alien by alienID
 func (w *WorldImpl) GetAlien(ctx context.Context, alienID int) (*types.Alien, error) {
 
@@ -194,23 +405,34 @@ func (w *WorldImpl) MoveAlien(ctx context.Context, alien *types.Alien, city *typ
 		return err
 	}
 
-	if alienFound == nil {
+	if alienFound == nil || alienFound != alien {
 		return types.ERR_UNKNOWN_ALIEN
 	}
 
+	if alien.IsTrapped {
+		return types.ERR_TRAPPED_ALIEN
+	}
+
 	cityFound, err := w.GetCity(ctx, city.Name)
 	if err != nil {
 		return err
 	}
 
-	if cityFound == nil {
+	if cityFound == nil || cityFound != city {
 		return types.ERR_UNKNOWN_CITY
 	}
 
+	if alienAtCity, found := w.alienInCities[city]; found && alienAtCity != alien {
+		return types.ERR_CITY_OCCUPIED
+	}
+
 	if alien.City != nil {
 		delete(w.alienInCities, alien.City)
 	}
 
+	alien.Road = nil
+	alien.Destination = nil
+	alien.StepsLeft = 0
 	alien.City = city
 	w.alienInCities[alien.City] = alien
 
@@ -230,7 +452,9 @@ func (w *WorldImpl) TrapAlien(ctx context.Context, alien *types.Alien) error {
 	}
 
 	if alienFound != nil {
-		delete(w.alienInCities, alienFound.City)
+		if w.alienInCities[alienFound.City] == alienFound {
+			delete(w.alienInCities, alienFound.City)
+		}
 		w.aliens[alienFound.AlienID].IsTrapped = true
 		return nil
 	}
@@ -271,7 +495,7 @@ func (w *WorldImpl) GetAlienAtCity(ctx context.Context, city *types.City) (*type
 		return alien, err
 	}
 
-	if cityFound == nil {
+	if cityFound == nil || cityFound != city {
 		return alien, types.ERR_UNKNOWN_CITY
 	}
 
@@ -282,7 +506,7 @@ func (w *WorldImpl) GetAlienAtCity(ctx context.Context, city *types.City) (*type
 	return alien, nil
 }
 
-// GetUntrappedAliens retrieves the list of untrapped alien
+// GetUntrappedAliens retrieves the list of untrapped alien, by ID
 func (w *WorldImpl) GetUntrappedAliens(ctx context.Context) ([]*types.Alien, error) {
 
 	var aliens []*types.Alien
@@ -292,5 +516,110 @@ func (w *WorldImpl) GetUntrappedAliens(ctx context.Context) ([]*types.Alien, err
 		}
 	}
 
+	sort.Slice(aliens, func(i, j int) bool { return aliens[i].AlienID < aliens[j].AlienID })
+	return aliens, nil
+}
+
+// SendAlien sends an alien from its city on the road leading in a given direction
+func (w *WorldImpl) SendAlien(ctx context.Context, alien *types.Alien, direction types.Direction) error {
+
+	if alien == nil {
+		return types.ERR_MISSING_ALIEN
+	}
+
+	alienFound, err := w.GetAlien(ctx, alien.AlienID)
+	if err != nil {
+		return err
+	}
+
+	if alienFound == nil || alienFound != alien {
+		return types.ERR_UNKNOWN_ALIEN
+	}
+
+	if alien.IsTrapped {
+		return types.ERR_TRAPPED_ALIEN
+	}
+
+	if alien.City == nil {
+		return types.ERR_ALIEN_NOT_IN_CITY
+	}
+
+	var road *types.Road
+	for _, cityRoad := range w.roads[alien.City] {
+		if cityRoad.Leads(alien.City, direction) {
+			road = cityRoad
+			break
+		}
+	}
+
+	if road == nil {
+		return types.ERR_MISSING_ROAD
+	}
+
+	delete(w.alienInCities, alien.City)
+	alien.Road = road
+	alien.Destination = road.Other(alien.City)
+	alien.StepsLeft = road.Length - 1
+	alien.City = nil
+
+	return nil
+}
+
+// TravelAlien moves an alien one step further on its road, returns true once it reached its destination
+func (w *WorldImpl) TravelAlien(ctx context.Context, alien *types.Alien) (bool, error) {
+
+	if alien == nil {
+		return false, types.ERR_MISSING_ALIEN
+	}
+
+	alienFound, err := w.GetAlien(ctx, alien.AlienID)
+	if err != nil {
+		return false, err
+	}
+
+	if alienFound == nil {
+		return false, types.ERR_UNKNOWN_ALIEN
+	}
+
+	if !alien.IsInTransit() {
+		return false, types.ERR_MISSING_ROAD
+	}
+
+	if alien.StepsLeft > 0 {
+		alien.StepsLeft--
+	}
+
+	return alien.StepsLeft == 0, nil
+}
+
+// GetAliensInTransit retrieves the list of untrapped aliens travelling on a road, by ID
+func (w *WorldImpl) GetAliensInTransit(ctx context.Context) ([]*types.Alien, error) {
+
+	var aliens []*types.Alien
+	for _, alien := range w.aliens {
+		if !alien.IsTrapped && alien.IsInTransit() {
+			aliens = append(aliens, alien)
+		}
+	}
+
+	sort.Slice(aliens, func(i, j int) bool { return aliens[i].AlienID < aliens[j].AlienID })
 	return aliens, nil
-}
\ No newline at end of file
+}
+
+// removeRoads removes roads from a list of roads
+func removeRoads(roads []*types.Road, removed ...*types.Road) []*types.Road {
+	filtered := roads[:0]
+	for _, r := range roads {
+		keep := true
+		for _, road := range removed {
+			if r == road {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
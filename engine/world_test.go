@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"alien-invasion-cc/engine/types"
@@ -358,7 +359,7 @@ func Test_World_LinkScenario(t *testing.T) {
 	require.ErrorIs(t, err, types.ERR_UNKNOWN_CITY)
 
 	// AddLink between CityA and CityB with unknown direction
-	err = world.AddLink(ctx, cityA, cityB, types.Direction(0))
+	err = world.AddLink(ctx, cityA, cityB, types.Direction(""))
 	require.ErrorIs(t, err, types.ERR_UNKNOWN_DIRECTION)
 
 	// AddLink between CityA and CityB for a direction works
@@ -372,4 +373,394 @@ func Test_World_LinkScenario(t *testing.T) {
 	// AddLink between CityA and CityC for a different direction works
 	err = world.AddLink(ctx, cityA, cityC, types.West)
 	require.NoError(t, err)
-}
\ No newline at end of file
+}
+
+func Test_World_RoadScenario(t *testing.T) {
+	ctx := context.Background()
+	world := NewWorld()
+
+	cityA, err := world.AddCity(ctx, "CityA")
+	require.NoError(t, err)
+	cityB, err := world.AddCity(ctx, "CityB")
+	require.NoError(t, err)
+	cityC, err := world.AddCity(ctx, "CityC")
+	require.NoError(t, err)
+	cityD, err := world.AddCity(ctx, "CityD")
+	require.NoError(t, err)
+
+	// Null road not allowed
+	err = world.AddRoad(ctx, nil)
+	require.ErrorIs(t, err, types.ERR_MISSING_ROAD)
+
+	// CityA leads north to CityB, listed on one side only
+	err = world.AddLink(ctx, cityA, cityB, types.North)
+	require.NoError(t, err)
+
+	roads, err := world.GetRoads(ctx)
+	require.NoError(t, err)
+	require.Len(t, roads, 1)
+	require.False(t, roads[0].TwoWay)
+	require.Equal(t, map[types.Direction]*types.City{}, cityB.GetAvailableLinks())
+
+	// Listing the same link again changes nothing
+	err = world.AddLink(ctx, cityA, cityB, types.North)
+	require.NoError(t, err)
+
+	roads, err = world.GetRoads(ctx)
+	require.NoError(t, err)
+	require.Len(t, roads, 1)
+
+	// Listing the way back turns the road into a two-way road
+	err = world.AddLink(ctx, cityB, cityA, types.South)
+	require.NoError(t, err)
+
+	roads, err = world.GetRoads(ctx)
+	require.NoError(t, err)
+	require.Len(t, roads, 1)
+	require.True(t, roads[0].TwoWay)
+
+	// A two-way road can't be added when the way back is taken
+	err = world.AddRoad(ctx, types.NewRoad(cityC, cityB, types.North, true))
+	require.ErrorIs(t, err, types.ERR_ALREADY_EXISTS_LINK)
+	require.Equal(t, map[types.Direction]*types.City{}, cityC.GetAvailableLinks())
+
+	// A two-way road sets both directions
+	err = world.AddRoad(ctx, types.NewRoad(cityC, cityB, types.South, true))
+	require.NoError(t, err)
+	require.Equal(t, map[types.Direction]*types.City{types.South: cityB}, cityC.GetAvailableLinks())
+	require.Equal(t, map[types.Direction]*types.City{types.South: cityA, types.North: cityC}, cityB.GetAvailableLinks())
+
+	// CityD leads twice to CityB
+	err = world.AddLink(ctx, cityD, cityB, types.East)
+	require.NoError(t, err)
+	err = world.AddLink(ctx, cityD, cityB, types.West)
+	require.NoError(t, err)
+
+	roadsB, err := world.GetCityRoads(ctx, cityB)
+	require.NoError(t, err)
+	require.Len(t, roadsB, 4)
+
+	// Destroying CityB removes every road touching it
+	err = world.DestroyCity(ctx, cityB)
+	require.NoError(t, err)
+
+	require.Equal(t, map[types.Direction]*types.City{}, cityA.GetAvailableLinks())
+	require.Equal(t, map[types.Direction]*types.City{}, cityC.GetAvailableLinks())
+	require.Equal(t, map[types.Direction]*types.City{}, cityD.GetAvailableLinks())
+	require.Equal(t, map[types.Direction]*types.City{}, cityB.GetAvailableLinks())
+
+	roads, err = world.GetRoads(ctx)
+	require.NoError(t, err)
+	require.Empty(t, roads)
+
+	roadsA, err := world.GetCityRoads(ctx, cityA)
+	require.NoError(t, err)
+	require.Empty(t, roadsA)
+
+	// Roads of a destroyed city can't be retrieved
+	_, err = world.GetCityRoads(ctx, cityB)
+	require.ErrorIs(t, err, types.ERR_UNKNOWN_CITY)
+}
+
+func Test_World_TransitScenario(t *testing.T) {
+	ctx := context.Background()
+	world := NewWorld()
+
+	cityA, err := world.AddCity(ctx, "CityA")
+	require.NoError(t, err)
+	cityB, err := world.AddCity(ctx, "CityB")
+	require.NoError(t, err)
+
+	// Road length must be at least one step
+	err = world.AddWeightedLink(ctx, cityA, cityB, types.East, 0)
+	require.ErrorIs(t, err, types.ERR_INVALID_ROAD_LENGTH)
+
+	// CityA leads east to CityB in 3 steps
+	err = world.AddWeightedLink(ctx, cityA, cityB, types.East, 3)
+	require.NoError(t, err)
+
+	// Both sides of a road must have the same length
+	err = world.AddWeightedLink(ctx, cityB, cityA, types.West, 2)
+	require.ErrorIs(t, err, types.ERR_ROAD_LENGTH_MISMATCH)
+	err = world.AddLink(ctx, cityA, cityB, types.East)
+	require.ErrorIs(t, err, types.ERR_ROAD_LENGTH_MISMATCH)
+
+	err = world.AddWeightedLink(ctx, cityB, cityA, types.West, 3)
+	require.NoError(t, err)
+	require.Equal(t, "CityB west=CityA:3", cityB.String())
+
+	alien1, err := world.AddAlien(ctx, 1)
+	require.NoError(t, err)
+
+	// Alien1 is not in a city yet
+	err = world.SendAlien(ctx, alien1, types.East)
+	require.ErrorIs(t, err, types.ERR_ALIEN_NOT_IN_CITY)
+
+	err = world.MoveAlien(ctx, alien1, cityA)
+	require.NoError(t, err)
+
+	// No road leads north from CityA
+	err = world.SendAlien(ctx, alien1, types.North)
+	require.ErrorIs(t, err, types.ERR_MISSING_ROAD)
+
+	// Alien1 leaves CityA
+	err = world.SendAlien(ctx, alien1, types.East)
+	require.NoError(t, err)
+	require.True(t, alien1.IsInTransit())
+	require.Nil(t, alien1.City)
+	require.Equal(t, cityB, alien1.Destination)
+	require.Equal(t, uint(2), alien1.StepsLeft)
+
+	alienFound, err := world.GetAlienAtCity(ctx, cityA)
+	require.NoError(t, err)
+	require.Nil(t, alienFound)
+
+	aliensInTransit, err := world.GetAliensInTransit(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alien{alien1}, aliensInTransit)
+
+	arrived, err := world.TravelAlien(ctx, alien1)
+	require.NoError(t, err)
+	require.False(t, arrived)
+
+	arrived, err = world.TravelAlien(ctx, alien1)
+	require.NoError(t, err)
+	require.True(t, arrived)
+
+	// Alien1 reaches CityB
+	err = world.MoveAlien(ctx, alien1, cityB)
+	require.NoError(t, err)
+	require.False(t, alien1.IsInTransit())
+
+	_, err = world.TravelAlien(ctx, alien1)
+	require.ErrorIs(t, err, types.ERR_MISSING_ROAD)
+
+	aliensInTransit, err = world.GetAliensInTransit(ctx)
+	require.NoError(t, err)
+	require.Empty(t, aliensInTransit)
+}
+
+// checkWorldInvariants verifies the consistency of the cities, roads and aliens of a world
+func checkWorldInvariants(w *WorldImpl) error {
+	for name, city := range w.cities {
+		if city.Name != name || city.IsDestroyed {
+			return fmt.Errorf("alive city %s is registered as %s or destroyed", city.Name, name)
+		}
+
+		for direction, target := range city.Links {
+			if target == nil || target.IsDestroyed || w.cities[target.Name] != target {
+				return fmt.Errorf("alive city %s points %s at a destroyed or unknown city", city.Name, direction)
+			}
+		}
+	}
+
+	for _, road := range w.roadList {
+		if w.cities[road.From.Name] != road.From || w.cities[road.To.Name] != road.To {
+			return fmt.Errorf("road %s touches a destroyed or unknown city", road)
+		}
+
+		if road.From.Links[road.Direction] != road.To {
+			return fmt.Errorf("road %s is not linked", road)
+		}
+	}
+
+	for city, alien := range w.alienInCities {
+		if alien.City != city {
+			return fmt.Errorf("%s is listed in %s but is elsewhere", alien, city.Name)
+		}
+
+		if alien.IsTrapped {
+			return fmt.Errorf("trapped %s occupies %s", alien, city.Name)
+		}
+
+		if w.cities[city.Name] != city {
+			return fmt.Errorf("%s occupies destroyed or unknown %s", alien, city.Name)
+		}
+
+		if w.aliens[alien.AlienID] != alien {
+			return fmt.Errorf("unknown %s occupies %s", alien, city.Name)
+		}
+	}
+
+	for _, alien := range w.aliens {
+		if alien.IsTrapped || alien.City == nil {
+			continue
+		}
+
+		if w.alienInCities[alien.City] != alien {
+			return fmt.Errorf("%s is in %s but not listed there", alien, alien.City.Name)
+		}
+	}
+
+	return nil
+}
+
+// worldOperation is an operation run on a world by FuzzWorld_Operations, from its operands
+type worldOperation func(ctx context.Context, w *WorldImpl, state *worldFuzzState, a, b, c byte) error
+
+// worldFuzzState keeps every city and alien created, destroyed ones included, so stale pointers get used
+type worldFuzzState struct {
+	cities []*types.City
+	aliens []*types.Alien
+}
+
+// city picks a city, or nil
+func (s *worldFuzzState) city(i byte) *types.City {
+	if len(s.cities) == 0 || i == 0xff {
+		return nil
+	}
+	return s.cities[int(i)%len(s.cities)]
+}
+
+// alien picks an alien, or nil
+func (s *worldFuzzState) alien(i byte) *types.Alien {
+	if len(s.aliens) == 0 || i == 0xff {
+		return nil
+	}
+	return s.aliens[int(i)%len(s.aliens)]
+}
+
+// fuzzDirections are the built-in directions and two invalid ones
+var fuzzDirections = []types.Direction{"", types.North, types.East, types.South, types.West, types.NorthEast,
+	types.SouthEast, types.SouthWest, types.NorthWest, types.Up, types.Down, "a=b"}
+
+// fuzzDirection picks a direction among the built-in ones, or an invalid one
+func fuzzDirection(c byte) types.Direction {
+	return fuzzDirections[int(c)%len(fuzzDirections)]
+}
+
+var worldOperations = []worldOperation{
+	func(ctx context.Context, w *WorldImpl, s *worldFuzzState, a, b, c byte) error {
+		city, err := w.AddCity(ctx, fmt.Sprintf("City%d", a%6))
+		if err == nil {
+			s.cities = append(s.cities, city)
+		}
+		return err
+	},
+	func(ctx context.Context, w *WorldImpl, s *worldFuzzState, a, b, c byte) error {
+		return w.AddWeightedLink(ctx, s.city(a), s.city(b), fuzzDirection(c), uint(c>>4)%4)
+	},
+	func(ctx context.Context, w *WorldImpl, s *worldFuzzState, a, b, c byte) error {
+		road := types.NewRoad(s.city(a), s.city(b), fuzzDirection(c), c&0x80 != 0)
+		road.Length = uint(c>>4)%3 + 1
+		return w.AddRoad(ctx, road)
+	},
+	func(ctx context.Context, w *WorldImpl, s *worldFuzzState, a, b, c byte) error {
+		city := s.city(a)
+		if city == nil {
+			return nil
+		}
+		return w.DestroyCity(ctx, city)
+	},
+	func(ctx context.Context, w *WorldImpl, s *worldFuzzState, a, b, c byte) error {
+		alien, err := w.AddAlien(ctx, int(a%5)+1)
+		if err == nil {
+			s.aliens = append(s.aliens, alien)
+		}
+		return err
+	},
+	func(ctx context.Context, w *WorldImpl, s *worldFuzzState, a, b, c byte) error {
+		return w.MoveAlien(ctx, s.alien(a), s.city(b))
+	},
+	func(ctx context.Context, w *WorldImpl, s *worldFuzzState, a, b, c byte) error {
+		return w.TrapAlien(ctx, s.alien(a))
+	},
+	func(ctx context.Context, w *WorldImpl, s *worldFuzzState, a, b, c byte) error {
+		return w.SendAlien(ctx, s.alien(a), fuzzDirection(c))
+	},
+	func(ctx context.Context, w *WorldImpl, s *worldFuzzState, a, b, c byte) error {
+		alien := s.alien(a)
+		arrived, err := w.TravelAlien(ctx, alien)
+		if err != nil || !arrived {
+			return err
+		}
+		return w.MoveAlien(ctx, alien, alien.Destination)
+	},
+	func(ctx context.Context, w *WorldImpl, s *worldFuzzState, a, b, c byte) error {
+		_, err := w.GetAlienAtCity(ctx, s.city(a))
+		return err
+	},
+	func(ctx context.Context, w *WorldImpl, s *worldFuzzState, a, b, c byte) error {
+		_, err := w.GetCityRoads(ctx, s.city(a))
+		return err
+	},
+}
+
+func FuzzWorld_Operations(f *testing.F) {
+	// build a map, land aliens, destroy a city and travel a long road
+	f.Add([]byte{
+		0, 0, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0,
+		1, 0, 1, 1, 1, 1, 2, 0x22, 2, 0, 2, 0x82,
+		4, 0, 0, 0, 4, 1, 0, 0, 5, 0, 0, 0, 5, 1, 1, 0,
+		7, 0, 0, 0x22, 8, 0, 0, 0, 8, 0, 0, 0,
+		6, 1, 0, 0, 3, 1, 0, 0, 0, 1, 0, 0, 5, 1, 3, 0,
+	})
+
+	f.Fuzz(func(t *testing.T, operations []byte) {
+		ctx := context.Background()
+		world := NewWorld()
+		state := &worldFuzzState{}
+
+		for i := 0; i+4 <= len(operations); i += 4 {
+			operation := worldOperations[int(operations[i])%len(worldOperations)]
+			_ = operation(ctx, world, state, operations[i+1], operations[i+2], operations[i+3])
+
+			if err := checkWorldInvariants(world); err != nil {
+				t.Fatalf("after operation %d: %v", i/4, err)
+			}
+		}
+	})
+}
+
+func Test_World_AlienConsistency(t *testing.T) {
+	ctx := context.Background()
+	world := NewWorld()
+
+	city1, err := world.AddCity(ctx, "City1")
+	require.NoError(t, err)
+	city2, err := world.AddCity(ctx, "City2")
+	require.NoError(t, err)
+	require.NoError(t, world.AddLink(ctx, city1, city2, types.North))
+
+	alien1, err := world.AddAlien(ctx, 1)
+	require.NoError(t, err)
+	alien2, err := world.AddAlien(ctx, 2)
+	require.NoError(t, err)
+	require.NoError(t, world.MoveAlien(ctx, alien1, city1))
+
+	// a city holds one alien at a time
+	err = world.MoveAlien(ctx, alien2, city1)
+	require.ErrorIs(t, err, types.ERR_CITY_OCCUPIED)
+	require.NoError(t, world.MoveAlien(ctx, alien2, city2))
+
+	// trapped aliens don't move any more
+	require.NoError(t, world.TrapAlien(ctx, alien2))
+	err = world.MoveAlien(ctx, alien2, city1)
+	require.ErrorIs(t, err, types.ERR_TRAPPED_ALIEN)
+	err = world.SendAlien(ctx, alien2, types.South)
+	require.ErrorIs(t, err, types.ERR_TRAPPED_ALIEN)
+
+	// an alien left in a destroyed city is trapped in its ruins
+	require.NoError(t, world.DestroyCity(ctx, city1))
+	require.True(t, alien1.IsTrapped)
+	require.NoError(t, checkWorldInvariants(world))
+
+	// a destroyed city is not the city registered again with its name
+	city1Again, err := world.AddCity(ctx, "City1")
+	require.NoError(t, err)
+	err = world.AddLink(ctx, city2, city1, types.South)
+	require.ErrorIs(t, err, types.ERR_UNKNOWN_CITY)
+	require.NoError(t, world.AddLink(ctx, city2, city1Again, types.South))
+
+	alien3, err := world.AddAlien(ctx, 3)
+	require.NoError(t, err)
+	err = world.MoveAlien(ctx, alien3, city1)
+	require.ErrorIs(t, err, types.ERR_UNKNOWN_CITY)
+	_, err = world.GetAlienAtCity(ctx, city1)
+	require.ErrorIs(t, err, types.ERR_UNKNOWN_CITY)
+
+	// a stale alien is not the alien registered with its ID
+	err = world.MoveAlien(ctx, types.NewAlien(3), city1Again)
+	require.ErrorIs(t, err, types.ERR_UNKNOWN_ALIEN)
+	require.NoError(t, checkWorldInvariants(world))
+}
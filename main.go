@@ -6,13 +6,18 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"alien-invasion-cc/cmd"
 	log "github.com/sirupsen/logrus"
 ) 
 func main() {
-	fmt.Println("=========================")
-	fmt.Println("Alien Invasion Simulator")
-	fmt.Println("=========================")
+	// the shell reads the completions written, the banner would be taken for one;
+	// it goes to stderr, maps and reports written to stdout being piped
+	if !cmd.Completing(os.Args[1:]) {
+		fmt.Fprintln(os.Stderr, "=========================")
+		fmt.Fprintln(os.Stderr, "Alien Invasion Simulator")
+		fmt.Fprintln(os.Stderr, "=========================")
+	}
 	cmd.Execute()
 }
 
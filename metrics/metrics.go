@@ -0,0 +1,365 @@
+package metrics
+
+import (
+	"bufio"
+	"expvar"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// ContentType is the content type of the Prometheus text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var ERR_ALREADY_PUBLISHED error = fmt.Errorf("an expvar variable is already published under this name")
+
+var (
+	// publishedMu guards the registries published
+	publishedMu sync.Mutex
+	// published holds the registry published under every name, expvar keeping its variables for good
+	published = make(map[string]*Registry)
+)
+
+// metric is a metric a Registry exposes
+type metric interface {
+	// kind retrieves the Prometheus type of the metric
+	kind() string
+	// write writes the samples of the metric in the Prometheus text format
+	write(w io.Writer, name string) error
+	// value retrieves the value of the metric as published through expvar
+	value() interface{}
+}
+
+// Registry holds named metrics and exposes them in the Prometheus text format and through expvar
+type Registry struct {
+	// mu guards the metrics
+	mu sync.Mutex
+
+	metrics map[string]metric
+
+	help map[string]string
+}
+
+// Generate New Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		metrics: make(map[string]metric),
+		help:    make(map[string]string),
+	}
+}
+
+// register adds a metric, or retrieves the metric already registered under the name
+func (r *Registry) register(name, help string, m metric) metric {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, found := r.metrics[name]; found {
+		if existing.kind() != m.kind() {
+			panic(fmt.Sprintf("metric %s is already registered as a %s", name, existing.kind()))
+		}
+		return existing
+	}
+
+	r.metrics[name] = m
+	r.help[name] = help
+	return m
+}
+
+// NewCounter registers a counter, or retrieves the counter already registered under the name
+func (r *Registry) NewCounter(name, help string) *Counter {
+	return r.register(name, help, &Counter{}).(*Counter)
+}
+
+// NewGauge registers a gauge, or retrieves the gauge already registered under the name
+func (r *Registry) NewGauge(name, help string) *Gauge {
+	return r.register(name, help, &Gauge{}).(*Gauge)
+}
+
+// NewHistogram registers a histogram with increasing bucket upper bounds, or retrieves the histogram
+// already registered under the name
+func (r *Registry) NewHistogram(name, help string, buckets []float64) *Histogram {
+	return r.register(name, help, newHistogram(buckets)).(*Histogram)
+}
+
+// names retrieves the names of the metrics, sorted
+func (r *Registry) names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup retrieves a metric and its help by name
+func (r *Registry) lookup(name string) (metric, string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.metrics[name], r.help[name]
+}
+
+// WritePrometheus writes every metric in the Prometheus text exposition format, sorted by name
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	for _, name := range r.names() {
+		m, help := r.lookup(name)
+
+		_, err := fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, m.kind())
+		if err != nil {
+			return err
+		}
+
+		err = m.write(bw, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Handler retrieves an HTTP handler serving the metrics in the Prometheus text format, for /metrics
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		_ = r.WritePrometheus(w)
+	})
+}
+
+// Snapshot retrieves the value of every metric by name, as published through expvar
+func (r *Registry) Snapshot() map[string]interface{} {
+	snapshot := make(map[string]interface{})
+	for _, name := range r.names() {
+		m, _ := r.lookup(name)
+		snapshot[name] = m.value()
+	}
+	return snapshot
+}
+
+// Publish publishes the metrics as an expvar variable, expvar serving its variables on /debug/vars;
+// publishing the registry again does nothing, a name taken by another variable is an error
+func (r *Registry) Publish(name string) error {
+	publishedMu.Lock()
+	defer publishedMu.Unlock()
+
+	if published[name] == r {
+		return nil
+	}
+
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("%w: %s", ERR_ALREADY_PUBLISHED, name)
+	}
+
+	expvar.Publish(name, expvar.Func(func() interface{} { return r.Snapshot() }))
+	published[name] = r
+	return nil
+}
+
+// ServeMux retrieves a mux serving the metrics on /metrics and the expvar variables on /debug/vars
+func (r *Registry) ServeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r.Handler())
+	mux.Handle("/debug/vars", expvar.Handler())
+	return mux
+}
+
+// Counter is a count only going up
+type Counter struct {
+	count uint64
+}
+
+// Inc adds one to the counter
+func (c *Counter) Inc() {
+	atomic.AddUint64(&c.count, 1)
+}
+
+// Add adds n to the counter
+func (c *Counter) Add(n uint64) {
+	atomic.AddUint64(&c.count, n)
+}
+
+// Value retrieves the count
+func (c *Counter) Value() uint64 {
+	return atomic.LoadUint64(&c.count)
+}
+
+func (c *Counter) kind() string {
+	return "counter"
+}
+
+func (c *Counter) write(w io.Writer, name string) error {
+	_, err := fmt.Fprintf(w, "%s %d\n", name, c.Value())
+	return err
+}
+
+func (c *Counter) value() interface{} {
+	return c.Value()
+}
+
+// Gauge is a value going up and down
+type Gauge struct {
+	bits uint64
+}
+
+// Set sets the value of the gauge
+func (g *Gauge) Set(v float64) {
+	atomic.StoreUint64(&g.bits, math.Float64bits(v))
+}
+
+// Add adds delta to the value of the gauge
+func (g *Gauge) Add(delta float64) {
+	for {
+		old := atomic.LoadUint64(&g.bits)
+		if atomic.CompareAndSwapUint64(&g.bits, old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+// Value retrieves the value of the gauge
+func (g *Gauge) Value() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&g.bits))
+}
+
+func (g *Gauge) kind() string {
+	return "gauge"
+}
+
+func (g *Gauge) write(w io.Writer, name string) error {
+	_, err := fmt.Fprintf(w, "%s %s\n", name, formatFloat(g.Value()))
+	return err
+}
+
+func (g *Gauge) value() interface{} {
+	return g.Value()
+}
+
+// Histogram counts observations in buckets by upper bound, plus their sum
+type Histogram struct {
+	// mu guards the counts and the sum
+	mu sync.Mutex
+
+	buckets []float64
+
+	counts []uint64
+
+	count uint64
+
+	sum float64
+}
+
+// HistogramValue is the value of a histogram as published through expvar
+type HistogramValue struct {
+	// Number of observations
+	Count uint64 `json:"count"`
+	// Sum of the observations
+	Sum float64 `json:"sum"`
+	// Number of observations lower than or equal to each bucket upper bound, cumulative
+	Buckets map[string]uint64 `json:"buckets"`
+}
+
+// newHistogram creates a histogram with increasing bucket upper bounds
+func newHistogram(buckets []float64) *Histogram {
+	bounds := make([]float64, len(buckets))
+	copy(bounds, buckets)
+	sort.Float64s(bounds)
+
+	return &Histogram{
+		buckets: bounds,
+		counts:  make([]uint64, len(bounds)),
+	}
+}
+
+// ExponentialBuckets retrieves count bucket upper bounds, from start on, each factor times the previous one
+func ExponentialBuckets(start, factor float64, count int) []float64 {
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start
+		start *= factor
+	}
+	return buckets
+}
+
+// Observe adds an observation
+func (h *Histogram) Observe(v float64) {
+	i := sort.SearchFloat64s(h.buckets, v)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if i < len(h.counts) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += v
+}
+
+// Value retrieves the observations counted so far
+func (h *Histogram) Value() HistogramValue {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	value := HistogramValue{
+		Count:   h.count,
+		Sum:     h.sum,
+		Buckets: make(map[string]uint64, len(h.buckets)),
+	}
+
+	cumulative := uint64(0)
+	for i, bound := range h.buckets {
+		cumulative += h.counts[i]
+		value.Buckets[formatFloat(bound)] = cumulative
+	}
+	return value
+}
+
+func (h *Histogram) kind() string {
+	return "histogram"
+}
+
+func (h *Histogram) write(w io.Writer, name string) error {
+	h.mu.Lock()
+	counts := make([]uint64, len(h.counts))
+	copy(counts, h.counts)
+	count, sum := h.count, h.sum
+	h.mu.Unlock()
+
+	cumulative := uint64(0)
+	for i, bound := range h.buckets {
+		cumulative += counts[i]
+		_, err := fmt.Fprintf(w, "%s_bucket{le=\"%s\"} %d\n", name, formatFloat(bound), cumulative)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n%s_sum %s\n%s_count %d\n", name, count, name, formatFloat(sum), name, count)
+	return err
+}
+
+func (h *Histogram) value() interface{} {
+	return h.Value()
+}
+
+// formatFloat formats a sample value as Prometheus expects it
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
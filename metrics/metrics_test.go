@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Registry_WritePrometheus(t *testing.T) {
+	registry := NewRegistry()
+	steps := registry.NewCounter("steps_total", "Steps executed.")
+	cities := registry.NewGauge("cities", "Cities of the map.")
+	duration := registry.NewHistogram("duration_seconds", "Time taken.", []float64{1, 0.1})
+
+	steps.Add(41)
+	steps.Inc()
+	cities.Set(10)
+	cities.Add(-2.5)
+	for _, v := range []float64{0.05, 0.1, 0.5, 3} {
+		duration.Observe(v)
+	}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, registry.WritePrometheus(out))
+	require.Equal(t, `# HELP cities Cities of the map.
+# TYPE cities gauge
+cities 7.5
+# HELP duration_seconds Time taken.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{le="0.1"} 2
+duration_seconds_bucket{le="1"} 3
+duration_seconds_bucket{le="+Inf"} 4
+duration_seconds_sum 3.65
+duration_seconds_count 4
+# HELP steps_total Steps executed.
+# TYPE steps_total counter
+steps_total 42
+`, out.String())
+
+	// registering a name again retrieves the metric registered
+	require.Same(t, steps, registry.NewCounter("steps_total", "Other help."))
+	require.Panics(t, func() { registry.NewGauge("steps_total", "Steps executed.") })
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, ContentType, recorder.Header().Get("Content-Type"))
+	require.Equal(t, out.String(), recorder.Body.String())
+}
+
+func Test_Registry_Publish(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounter("steps_total", "Steps executed.").Add(3)
+	registry.NewHistogram("duration_seconds", "Time taken.", ExponentialBuckets(1, 10, 3)).Observe(20)
+
+	require.NoError(t, registry.Publish("metrics_test"))
+	require.NoError(t, registry.Publish("metrics_test"))
+
+	// another registry isn't published over the first one
+	require.ErrorIs(t, NewRegistry().Publish("metrics_test"), ERR_ALREADY_PUBLISHED)
+
+	snapshot := map[string]json.RawMessage{}
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("metrics_test").String()), &snapshot))
+	require.JSONEq(t, `3`, string(snapshot["steps_total"]))
+	require.JSONEq(t, `{"count":1,"sum":20,"buckets":{"1":0,"10":0,"100":1}}`, string(snapshot["duration_seconds"]))
+
+	recorder := httptest.NewRecorder()
+	registry.ServeMux().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `"metrics_test"`)
+}
+
+func Test_Metrics_Concurrency(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.NewCounter("count", "Count.")
+	gauge := registry.NewGauge("gauge", "Gauge.")
+	histogram := registry.NewHistogram("histogram", "Histogram.", []float64{1})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				counter.Inc()
+				gauge.Add(1)
+				histogram.Observe(0.5)
+			}
+		}()
+	}
+
+	// the metrics are written while being updated
+	require.NoError(t, registry.WritePrometheus(&bytes.Buffer{}))
+	wg.Wait()
+
+	require.Equal(t, uint64(8000), counter.Value())
+	require.Equal(t, float64(8000), gauge.Value())
+	require.Equal(t, uint64(8000), histogram.Value().Buckets["1"])
+}
@@ -0,0 +1,165 @@
+package scenario
+
+import (
+	"fmt"
+	"strings"
+
+	"alien-invasion-cc/engine"
+)
+
+var (
+	ERR_UNKNOWN_TERMINATION error = fmt.Errorf("unknown termination reason")
+
+	ERR_INVALID_EXPECTATION error = fmt.Errorf("invalid expectation")
+)
+
+// terminations lists the reasons a scenario may expect an invasion to end for
+var terminations = []engine.TerminationReason{
+	engine.TerminationMaxMoves,
+	engine.TerminationAllTrapped,
+	engine.TerminationAllDestroyed,
+	engine.TerminationStalemate,
+	engine.TerminationNoFights,
+	engine.TerminationCancelled,
+}
+
+// TrappedAlien Type definition, an alien expected to be trapped
+type TrappedAlien struct {
+	// Alien ID
+	Alien int `yaml:"alien"`
+	// Step the alien is trapped at the latest, any step when 0
+	ByStep uint `yaml:"by_step,omitempty"`
+}
+
+// Expectations Type definition, the outcome a scenario expects, unset values being left unchecked
+type Expectations struct {
+	// Reason the invasion ends for, e.g. max_moves or all_trapped
+	Termination string `yaml:"termination,omitempty"`
+	// Exact number of destroyed cities
+	Destroyed *int `yaml:"destroyed,omitempty"`
+	// Exact number of cities left standing
+	Remaining *int `yaml:"remaining,omitempty"`
+	// Cities left standing
+	Survive []string `yaml:"survive,omitempty"`
+	// Cities destroyed
+	Destroy []string `yaml:"destroy,omitempty"`
+	// Aliens trapped
+	Trapped []TrappedAlien `yaml:"trapped,omitempty"`
+}
+
+// Failure Type definition, an expectation the outcome of a scenario does not meet
+type Failure struct {
+	// Expectation which is not met
+	Expectation string `json:"expectation"`
+	// Expected value
+	Want string `json:"want"`
+	// Value reached
+	Got string `json:"got"`
+}
+
+// String output of Failure
+func (f Failure) String() string {
+	return fmt.Sprintf("%s: want %s, got %s", f.Expectation, f.Want, f.Got)
+}
+
+// Count retrieves the number of expectations
+func (e *Expectations) Count() int {
+	count := len(e.Survive) + len(e.Destroy) + len(e.Trapped)
+	for _, set := range []bool{e.Termination != "", e.Destroyed != nil, e.Remaining != nil} {
+		if set {
+			count++
+		}
+	}
+	return count
+}
+
+// termination retrieves the expected termination reason, dashes standing for underscores
+func (e *Expectations) termination() engine.TerminationReason {
+	return engine.TerminationReason(strings.ReplaceAll(e.Termination, "-", "_"))
+}
+
+// validate checks the expectations can be met
+func (e *Expectations) validate() error {
+	if e.Termination != "" {
+		known := false
+		for _, reason := range terminations {
+			known = known || e.termination() == reason
+		}
+
+		if !known {
+			return fmt.Errorf("%w: %q", ERR_UNKNOWN_TERMINATION, e.Termination)
+		}
+	}
+
+	for _, trapped := range e.Trapped {
+		if trapped.Alien < 1 {
+			return fmt.Errorf("%w: trapped alien #%d, IDs start at 1", ERR_INVALID_EXPECTATION, trapped.Alien)
+		}
+	}
+
+	return nil
+}
+
+// Check retrieves the expectations the outcome of a scenario does not meet, in the order they are listed
+func (e *Expectations) Check(outcome *Outcome) []Failure {
+	failures := []Failure{}
+	result := outcome.Result
+
+	if e.Termination != "" && e.termination() != result.Termination {
+		failures = append(failures, Failure{Expectation: "termination", Want: string(e.termination()), Got: string(result.Termination)})
+	}
+
+	if e.Destroyed != nil && *e.Destroyed != result.DestroyedCities {
+		failures = append(failures, Failure{Expectation: "destroyed", Want: fmt.Sprint(*e.Destroyed), Got: fmt.Sprint(result.DestroyedCities)})
+	}
+
+	if e.Remaining != nil && *e.Remaining != result.RemainingCities {
+		failures = append(failures, Failure{Expectation: "remaining", Want: fmt.Sprint(*e.Remaining), Got: fmt.Sprint(result.RemainingCities)})
+	}
+
+	cities := make(map[string]engine.CityState, len(result.Cities))
+	for _, city := range result.Cities {
+		cities[city.Name] = city
+	}
+
+	cityState := func(name string) string {
+		city, found := cities[name]
+		switch {
+		case !found:
+			return "unknown"
+		case city.Destroyed:
+			return "destroyed"
+		}
+		return "standing"
+	}
+
+	for _, name := range e.Survive {
+		if got := cityState(name); got != "standing" {
+			failures = append(failures, Failure{Expectation: "survive " + name, Want: "standing", Got: got})
+		}
+	}
+
+	for _, name := range e.Destroy {
+		if got := cityState(name); got != "destroyed" {
+			failures = append(failures, Failure{Expectation: "destroy " + name, Want: "destroyed", Got: got})
+		}
+	}
+
+	for _, trapped := range e.Trapped {
+		expectation := fmt.Sprintf("trapped alien #%d", trapped.Alien)
+		want := "trapped"
+		if trapped.ByStep > 0 {
+			want = fmt.Sprintf("trapped by step %d", trapped.ByStep)
+		}
+
+		step, found := outcome.TrappedAt[trapped.Alien]
+		switch {
+		case !found:
+			failures = append(failures, Failure{Expectation: expectation, Want: want, Got: "not trapped"})
+		case trapped.ByStep > 0 && step > trapped.ByStep:
+			failures = append(failures, Failure{Expectation: expectation, Want: want, Got: fmt.Sprintf("trapped at step %d", step)})
+		}
+	}
+
+	return failures
+}
@@ -0,0 +1,64 @@
+package scenario
+
+import (
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Expectations_Check(t *testing.T) {
+	outcome := &Outcome{
+		Result: &engine.Result{
+			Termination:     engine.TerminationAllTrapped,
+			RemainingCities: 1,
+			DestroyedCities: 1,
+			Cities:          []engine.CityState{{Name: "A"}, {Name: "B", Destroyed: true}},
+		},
+		TrappedAt: map[int]uint{1: 4, 2: 4},
+	}
+
+	s, err := Decode(strings.NewReader(`version: 1
+expect:
+  termination: all-trapped
+  destroyed: 1
+  remaining: 1
+  survive: [A]
+  destroy: [B]
+  trapped:
+    - alien: 1
+      by_step: 4
+    - alien: 2
+`), "")
+	require.NoError(t, err)
+	require.Equal(t, 7, s.Expect.Count())
+	require.Empty(t, s.Expect.Check(outcome))
+
+	s, err = Decode(strings.NewReader(`version: 1
+expect:
+  termination: max_moves
+  destroyed: 2
+  remaining: 0
+  survive: [B, C]
+  destroy: [A]
+  trapped:
+    - alien: 1
+      by_step: 3
+    - alien: 3
+`), "")
+	require.NoError(t, err)
+
+	failures := s.Expect.Check(outcome)
+	require.Equal(t, []Failure{
+		{Expectation: "termination", Want: "max_moves", Got: "all_trapped"},
+		{Expectation: "destroyed", Want: "2", Got: "1"},
+		{Expectation: "remaining", Want: "0", Got: "1"},
+		{Expectation: "survive B", Want: "standing", Got: "destroyed"},
+		{Expectation: "survive C", Want: "standing", Got: "unknown"},
+		{Expectation: "destroy A", Want: "destroyed", Got: "standing"},
+		{Expectation: "trapped alien #1", Want: "trapped by step 3", Got: "trapped at step 4"},
+		{Expectation: "trapped alien #3", Want: "trapped", Got: "not trapped"},
+	}, failures)
+	require.Equal(t, "termination: want max_moves, got all_trapped", failures[0].String())
+}
@@ -0,0 +1,212 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"alien-invasion-cc/engine"
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+)
+
+const (
+	// DefaultAliens is the number of aliens landing when a scenario sets none
+	DefaultAliens = 5
+	// DefaultSteps is the maximum number of steps when a scenario sets none
+	DefaultSteps = 10000
+	// DefaultSeed is the seed a scenario is run with when it sets none, its outcome always being the same
+	DefaultSeed = 1
+)
+
+var ERR_MISSING_MAP error = fmt.Errorf("the scenario has no map")
+
+// Outcome Type definition, how the invasion of a scenario ended
+type Outcome struct {
+	// Final state of the simulation
+	Result *engine.Result
+	// Step every trapped alien was trapped at, by alien ID
+	TrappedAt map[int]uint
+}
+
+// Run runs the scenario on its own values, writing the simulation output to out
+func (s *Scenario) Run(ctx context.Context, out io.Writer) (*Outcome, error) {
+	if s.Timeout != nil && *s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *s.Timeout)
+		defer cancel()
+	}
+
+	in, format, err := s.openMap()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = in.Close() }()
+
+	opts, err := s.EngineOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	var aliens uint = DefaultAliens
+	if s.Aliens != nil {
+		aliens = *s.Aliens
+	}
+
+	var steps uint = DefaultSteps
+	if s.Steps != nil {
+		steps = *s.Steps
+	}
+
+	outcome := &Outcome{TrappedAt: make(map[int]uint)}
+	opts = append(opts, engine.WithMapFormat(format), engine.WithEventListener(outcome.observe))
+	e := engine.NewEngine(aliens, steps, in, out, opts...)
+
+	err = e.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outcome.Result, err = e.Result(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return outcome, nil
+}
+
+// EngineOptions builds the engine options of the scenario, the values it leaves unset taking their defaults and
+// the seed DefaultSeed; the scenarios run by Test and by run --scenario are set up alike
+func (s *Scenario) EngineOptions() ([]engine.Option, error) {
+	directions := types.ClassicDirections
+	if s.Map.Directions != "" {
+		var err error
+		directions, err = types.GetDirectionProfile(s.Map.Directions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var seed int64 = DefaultSeed
+	if s.Seed != nil {
+		seed = *s.Seed
+	}
+
+	return []engine.Option{
+		engine.WithDirectionProfile(directions),
+		engine.WithAutoReverseRoads(s.Map.TwoWay != nil && *s.Map.TwoWay),
+		engine.WithRoadFights(s.RoadFights != nil && *s.RoadFights),
+		engine.WithPositionsReport(s.Reports.Positions != nil && *s.Reports.Positions),
+		engine.WithFragmentationReport(s.Reports.Fragmentation != nil && *s.Reports.Fragmentation),
+		engine.WithPlacement(s.Placement),
+		engine.WithSeed(seed),
+	}, nil
+}
+
+// observe records the step aliens get trapped at
+func (o *Outcome) observe(event engine.Event) {
+	switch event.Type {
+	case engine.EventFought, engine.EventRoadFight, engine.EventStranded:
+		for _, alienID := range event.Aliens {
+			if _, found := o.TrappedAt[alienID]; !found {
+				o.TrappedAt[alienID] = event.Step
+			}
+		}
+	}
+}
+
+// openMap opens the map of the scenario, with its format
+func (s *Scenario) openMap() (io.ReadCloser, mapfile.Format, error) {
+	format, err := mapfile.ParseFormat(s.Map.Format)
+	if s.Map.Format == "" {
+		format, err = mapfile.FormatAuto, nil
+	}
+	if err != nil {
+		return nil, format, err
+	}
+
+	if s.Map.Inline != "" {
+		return io.NopCloser(strings.NewReader(s.Map.Inline)), format, nil
+	}
+
+	path := s.MapPath()
+	if path == "" {
+		return nil, format, ERR_MISSING_MAP
+	}
+
+	if format == mapfile.FormatAuto {
+		format = mapfile.FormatFromPath(path)
+	}
+
+	in, err := mapfile.Open(path, os.Stdin)
+	if err != nil {
+		return nil, format, err
+	}
+	return in, format, nil
+}
+
+// Report Type definition, the outcome of a scenario file checked against its expectations
+type Report struct {
+	// Path of the scenario file
+	Path string `json:"path"`
+	// Scenario name
+	Name string `json:"name,omitempty"`
+	// Number of expectations checked
+	Expectations int `json:"expectations"`
+	// Expectations not met
+	Failures []Failure `json:"failures,omitempty"`
+	// Error the scenario could not be loaded or run for
+	Err error `json:"-"`
+}
+
+// Passed checks the scenario ran and met every expectation
+func (r *Report) Passed() bool {
+	return r.Err == nil && len(r.Failures) == 0
+}
+
+// Test runs a scenario file and checks its expectations, the simulation output being written to out
+func Test(ctx context.Context, path string, out io.Writer) *Report {
+	report := &Report{Path: path}
+
+	s, err := Load(path)
+	if err != nil {
+		report.Err = err
+		return report
+	}
+
+	report.Name = s.Name
+	report.Expectations = s.Expect.Count()
+
+	outcome, err := s.Run(ctx, out)
+	if err != nil {
+		report.Err = err
+		return report
+	}
+
+	report.Failures = s.Expect.Check(outcome)
+	return report
+}
+
+// Files retrieves the scenario files of a directory, .yaml and .yml files by name
+func Files(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, entry := range entries {
+		extension := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (extension != ".yaml" && extension != ".yml") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,77 @@
+package scenario
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Scenario_Run(t *testing.T) {
+	ctx := context.Background()
+
+	// two aliens placed at both ends of a one-way road meet on the first step
+	s, err := Decode(strings.NewReader("version: 1\nmap:\n  inline: |\n    A east=B\n    B\naliens: 2\nplacement:\n  1: A\n  2: B\n"), "")
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	outcome, err := s.Run(ctx, out)
+	require.NoError(t, err)
+	require.Equal(t, engine.TerminationAllTrapped, outcome.Result.Termination)
+	require.Equal(t, uint(1), outcome.Result.Steps)
+	require.Equal(t, map[int]uint{1: 1, 2: 1}, outcome.TrappedAt)
+	require.Contains(t, out.String(), "B has been destroyed by Alien #1 and Alien #2")
+
+	// the same scenario always ends the same way, with DefaultSeed
+	s, err = Load("../test_data/scenarios/europe.yaml")
+	require.NoError(t, err)
+	first, err := s.Run(ctx, io.Discard)
+	require.NoError(t, err)
+	second, err := s.Run(ctx, io.Discard)
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	s, err = Decode(strings.NewReader("version: 1\n"), "")
+	require.NoError(t, err)
+	_, err = s.Run(ctx, io.Discard)
+	require.ErrorIs(t, err, ERR_MISSING_MAP)
+}
+
+func Test_Test(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	passing := write("passing.yaml", "version: 1\nname: passing\nmap:\n  inline: A\naliens: 1\nexpect:\n  termination: stalemate\n  survive: [A]\n")
+	failing := write("failing.yml", "version: 1\nmap:\n  inline: A\naliens: 1\nexpect:\n  destroy: [A]\n")
+	broken := write("broken.yaml", "version: 2\n")
+	write("notes.txt", "not a scenario")
+
+	files, err := Files(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{broken, failing, passing}, files)
+
+	report := Test(ctx, passing, io.Discard)
+	require.True(t, report.Passed())
+	require.Equal(t, "passing", report.Name)
+	require.Equal(t, 2, report.Expectations)
+
+	report = Test(ctx, failing, io.Discard)
+	require.False(t, report.Passed())
+	require.Equal(t, []Failure{{Expectation: "destroy A", Want: "destroyed", Got: "standing"}}, report.Failures)
+
+	report = Test(ctx, broken, io.Discard)
+	require.False(t, report.Passed())
+	require.ErrorIs(t, report.Err, ERR_UNSUPPORTED_VERSION)
+}
@@ -0,0 +1,213 @@
+// Package scenario reads scenario files, describing a complete invasion experiment in one versioned file
+package scenario
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the scenario file version this package reads
+const Version = 1
+
+var (
+	ERR_UNSUPPORTED_VERSION error = fmt.Errorf("unsupported scenario version")
+
+	ERR_AMBIGUOUS_MAP error = fmt.Errorf("a scenario map is either a file or inline, not both")
+
+	ERR_INVALID_PLACEMENT error = fmt.Errorf("invalid alien placement")
+
+	ERR_UNSUPPORTED_STRATEGY error = fmt.Errorf("movement strategies other than random are not implemented")
+
+	ERR_UNSUPPORTED_WAVES error = fmt.Errorf("alien waves are not implemented")
+)
+
+// StrategyRandom is the only movement strategy the engine implements, every alien taking a random road each step
+const StrategyRandom = "random"
+
+// Map Type definition, the map a scenario is run on
+type Map struct {
+	// Path of the map file, relative to the scenario file
+	File string `yaml:"file,omitempty"`
+	// Map written in the scenario itself
+	Inline string `yaml:"inline,omitempty"`
+	// Map format: auto, classic, json or yaml
+	Format string `yaml:"format,omitempty"`
+	// Directions the map may use: classic, compass, layered or free
+	Directions string `yaml:"directions,omitempty"`
+	// Flag whether the way back of roads listed on only one side of the map is created
+	TwoWay *bool `yaml:"two_way,omitempty"`
+}
+
+// Reports Type definition, the optional reports of a scenario
+type Reports struct {
+	// Flag whether the city every alien left stands in is reported
+	Positions *bool `yaml:"positions,omitempty"`
+	// Flag whether how the destruction split the map is reported
+	Fragmentation *bool `yaml:"fragmentation,omitempty"`
+}
+
+// Wave Type definition, aliens landing together; waves are not implemented, a scenario may only write down the
+// single wave of every alien landing at step 0
+type Wave struct {
+	// Step the aliens land at
+	Step uint `yaml:"step"`
+	// Number of aliens landing, all the aliens when 0
+	Aliens uint `yaml:"aliens,omitempty"`
+}
+
+// Scenario Type definition, a complete invasion experiment, unset values keeping the command line defaults
+type Scenario struct {
+	// Scenario file version, Version
+	Version int `yaml:"version"`
+	// Short name of the experiment
+	Name string `yaml:"name,omitempty"`
+	// What the experiment is about
+	Description string `yaml:"description,omitempty"`
+	// Map the invasion is run on
+	Map Map `yaml:"map,omitempty"`
+	// Number of aliens landing
+	Aliens *uint `yaml:"aliens,omitempty"`
+	// City some aliens land in, by alien ID, the other aliens landing in random cities
+	Placement map[int]string `yaml:"placement,omitempty"`
+	// Waves the aliens land in; not implemented, only a single wave of every alien at step 0 is accepted
+	Waves []Wave `yaml:"waves,omitempty"`
+	// Movement strategy of the aliens; not implemented, only StrategyRandom is accepted
+	Strategy string `yaml:"strategy,omitempty"`
+	// Seed of the invasion
+	Seed *int64 `yaml:"seed,omitempty"`
+	// Maximum number of steps
+	Steps *uint `yaml:"steps,omitempty"`
+	// Flag whether aliens crossing each other on a road fight
+	RoadFights *bool `yaml:"road_fights,omitempty"`
+	// Duration after which the run is cancelled
+	Timeout *time.Duration `yaml:"timeout,omitempty"`
+	// Optional reports
+	Reports Reports `yaml:"reports,omitempty"`
+	// Outcome expected, checked by the test command
+	Expect Expectations `yaml:"expect,omitempty"`
+
+	// directory the map file path is relative to
+	dir string
+}
+
+// Load reads a scenario file
+func Load(path string) (*Scenario, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = in.Close() }()
+
+	s, err := Decode(in, filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Decode reads a scenario, its map file path being relative to a directory; unknown fields are rejected
+// so that no parameter of an experiment is silently lost
+func Decode(in io.Reader, dir string) (*Scenario, error) {
+	decoder := yaml.NewDecoder(in)
+	decoder.KnownFields(true)
+
+	s := &Scenario{dir: dir}
+	err := decoder.Decode(s)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	err = s.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// validate checks the scenario can be run
+func (s *Scenario) validate() error {
+	if s.Version != Version {
+		return fmt.Errorf("%w: %d, expected %d", ERR_UNSUPPORTED_VERSION, s.Version, Version)
+	}
+
+	if s.Map.File != "" && s.Map.Inline != "" {
+		return ERR_AMBIGUOUS_MAP
+	}
+
+	if s.Strategy != "" && s.Strategy != StrategyRandom {
+		return fmt.Errorf("%w: %q", ERR_UNSUPPORTED_STRATEGY, s.Strategy)
+	}
+
+	err := s.checkWaves()
+	if err != nil {
+		return err
+	}
+
+	err = s.Expect.validate()
+	if err != nil {
+		return err
+	}
+
+	if s.Aliens != nil {
+		return s.CheckPlacement(*s.Aliens)
+	}
+	return s.CheckPlacement(0)
+}
+
+// checkWaves checks the waves are the single wave of every alien landing at step 0, the engine implementing no other
+func (s *Scenario) checkWaves() error {
+	if len(s.Waves) == 0 {
+		return nil
+	}
+
+	if len(s.Waves) > 1 || s.Waves[0].Step != 0 {
+		return fmt.Errorf("%w: every alien lands at step 0", ERR_UNSUPPORTED_WAVES)
+	}
+
+	wave := s.Waves[0]
+	if wave.Aliens != 0 && (s.Aliens == nil || wave.Aliens != *s.Aliens) {
+		return fmt.Errorf("%w: the wave at step 0 lands every alien", ERR_UNSUPPORTED_WAVES)
+	}
+	return nil
+}
+
+// CheckPlacement checks every placed alien is one of a number of aliens landing, any alien when the number is 0
+func (s *Scenario) CheckPlacement(aliens uint) error {
+	alienIDs := make([]int, 0, len(s.Placement))
+	for alienID := range s.Placement {
+		alienIDs = append(alienIDs, alienID)
+	}
+	sort.Ints(alienIDs)
+
+	for _, alienID := range alienIDs {
+		if alienID < 1 {
+			return fmt.Errorf("%w: alien #%d, IDs start at 1", ERR_INVALID_PLACEMENT, alienID)
+		}
+
+		if aliens > 0 && uint(alienID) > aliens {
+			return fmt.Errorf("%w: alien #%d, only %d aliens land", ERR_INVALID_PLACEMENT, alienID, aliens)
+		}
+
+		if strings.TrimSpace(s.Placement[alienID]) == "" {
+			return fmt.Errorf("%w: alien #%d has no city", ERR_INVALID_PLACEMENT, alienID)
+		}
+	}
+
+	return nil
+}
+
+// MapPath retrieves the path of the map file, resolved from the scenario file directory, empty if there is none
+func (s *Scenario) MapPath() string {
+	if s.Map.File == "" || filepath.IsAbs(s.Map.File) {
+		return s.Map.File
+	}
+	return filepath.Join(s.dir, s.Map.File)
+}
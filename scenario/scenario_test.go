@@ -0,0 +1,123 @@
+package scenario
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Load(t *testing.T) {
+	s, err := Load("../test_data/scenarios/grid-road-fights.yaml")
+	require.NoError(t, err)
+
+	require.Equal(t, "grid-road-fights", s.Name)
+	require.Equal(t, filepath.Join("..", "test_data", "scenarios", "..", "test_map2"), s.MapPath())
+	require.Equal(t, uint(300), *s.Aliens)
+	require.Equal(t, map[int]string{1: "City_1_1", 2: "City_25_25"}, s.Placement)
+	require.Equal(t, int64(3), *s.Seed)
+	require.Equal(t, uint(100), *s.Steps)
+	require.True(t, *s.RoadFights)
+	require.Nil(t, s.Timeout)
+	require.Nil(t, s.Reports.Positions)
+	require.True(t, *s.Reports.Fragmentation)
+	require.Equal(t, 7, s.Expect.Count())
+
+	_, err = Load("../test_data/missing.yaml")
+	require.Error(t, err)
+}
+
+func Test_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr error
+		errText string
+	}{
+		{
+			name: "inline map and timeout",
+			in:   "version: 1\nmap:\n  inline: |\n    A east=B\ntimeout: 1m30s\n",
+		},
+		{
+			name:    "missing version",
+			in:      "aliens: 2\n",
+			wantErr: ERR_UNSUPPORTED_VERSION,
+			errText: "unsupported scenario version: 0, expected 1",
+		},
+		{
+			name:    "map file and inline map",
+			in:      "version: 1\nmap:\n  file: world.map\n  inline: A\n",
+			wantErr: ERR_AMBIGUOUS_MAP,
+		},
+		{
+			name:    "alien placed beyond the aliens landing",
+			in:      "version: 1\naliens: 2\nplacement:\n  3: Paris\n",
+			wantErr: ERR_INVALID_PLACEMENT,
+			errText: "invalid alien placement: alien #3, only 2 aliens land",
+		},
+		{
+			name:    "alien placed nowhere",
+			in:      "version: 1\nplacement:\n  1: ''\n",
+			wantErr: ERR_INVALID_PLACEMENT,
+		},
+		{
+			name:    "unknown termination reason",
+			in:      "version: 1\nexpect:\n  termination: victory\n",
+			wantErr: ERR_UNKNOWN_TERMINATION,
+			errText: `unknown termination reason: "victory"`,
+		},
+		{
+			name:    "unsupported strategy",
+			in:      "version: 1\nstrategy: greedy\n",
+			wantErr: ERR_UNSUPPORTED_STRATEGY,
+			errText: `movement strategies other than random are not implemented: "greedy"`,
+		},
+		{
+			name:    "several waves",
+			in:      "version: 1\naliens: 4\nwaves:\n  - step: 0\n    aliens: 2\n  - step: 10\n    aliens: 2\n",
+			wantErr: ERR_UNSUPPORTED_WAVES,
+		},
+		{
+			name:    "wave landing after startup",
+			in:      "version: 1\nwaves:\n  - step: 5\n",
+			wantErr: ERR_UNSUPPORTED_WAVES,
+			errText: "alien waves are not implemented: every alien lands at step 0",
+		},
+		{
+			name:    "single wave landing part of the aliens",
+			in:      "version: 1\naliens: 4\nwaves:\n  - step: 0\n    aliens: 2\n",
+			wantErr: ERR_UNSUPPORTED_WAVES,
+		},
+		{
+			name: "random strategy and a single wave",
+			in:   "version: 1\nmap:\n  inline: |\n    A east=B\ntimeout: 1m30s\naliens: 4\nstrategy: random\nwaves:\n  - step: 0\n    aliens: 4\n",
+		},
+		{
+			name:    "unknown field",
+			in:      "version: 1\ngravity: high\n",
+			errText: "yaml: unmarshal errors:\n  line 2: field gravity not found in type scenario.Scenario",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Decode(strings.NewReader(tt.in), "scenarios")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+			if tt.errText != "" {
+				require.EqualError(t, err, tt.errText)
+			}
+			if tt.wantErr != nil || tt.errText != "" {
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "A east=B\n", s.Map.Inline)
+			require.Empty(t, s.MapPath())
+			require.Equal(t, 90*time.Second, *s.Timeout)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Package scenariotest runs scenario files as Go tests, turning a directory of scenarios into a regression suite
+package scenariotest
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/scenario"
+)
+
+// Run runs every scenario file of a directory as a subtest named after the file,
+// reporting every expectation not met as an error of its subtest
+func Run(t *testing.T, dir string) {
+	t.Helper()
+
+	files, err := scenario.Files(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		file := file
+		name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		t.Run(name, func(t *testing.T) {
+			report := scenario.Test(context.Background(), file, io.Discard)
+			if report.Err != nil {
+				t.Fatal(report.Err)
+			}
+
+			for _, failure := range report.Failures {
+				t.Error(failure)
+			}
+		})
+	}
+}
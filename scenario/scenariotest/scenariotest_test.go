@@ -0,0 +1,9 @@
+package scenariotest
+
+import (
+	"testing"
+)
+
+func Test_Run(t *testing.T) {
+	Run(t, "../../test_data/scenarios")
+}
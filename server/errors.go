@@ -0,0 +1,31 @@
+package server
+
+import (
+	"fmt"
+)
+
+var (
+	ERR_UNKNOWN_MAP error = fmt.Errorf("map is unknown")
+
+	ERR_UNKNOWN_SIMULATION error = fmt.Errorf("simulation is unknown")
+
+	ERR_TOO_MANY_SIMULATIONS error = fmt.Errorf("too many simulations")
+
+	ERR_TOO_MANY_MAPS error = fmt.Errorf("too many maps")
+
+	ERR_SIMULATION_BUSY error = fmt.Errorf("simulation is already running")
+
+	ERR_SIMULATION_NOT_FINISHED error = fmt.Errorf("simulation is not finished")
+
+	ERR_SIMULATION_DELETED error = fmt.Errorf("simulation was deleted")
+
+	ERR_MAP_TOO_LARGE error = fmt.Errorf("map is larger than the maximum map size")
+
+	ERR_INVALID_REQUEST error = fmt.Errorf("invalid request")
+
+	ERR_NOT_FOUND error = fmt.Errorf("no such resource")
+
+	ERR_METHOD_NOT_ALLOWED error = fmt.Errorf("method not allowed")
+
+	ERR_STREAMING_UNSUPPORTED error = fmt.Errorf("the connection does not support streaming")
+)
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"sync"
+
+	"alien-invasion-cc/engine"
+)
+
+// DefaultMaxEvents is the number of events kept per simulation
+const DefaultMaxEvents = 100000
+
+// eventLog keeps the latest events of a simulation, numbered from 0 in the order they happened
+type eventLog struct {
+	mu sync.Mutex
+
+	events []engine.Event
+
+	// offset of events[0], the older events were dropped
+	first int64
+
+	limit int
+
+	// notify is closed by the next append, for the streams waiting for events
+	notify chan struct{}
+
+	// finished is set once the simulation finished, no event follows
+	finished bool
+}
+
+// newEventLog creates an event log keeping about limit events, all of them when limit is lower than or equal to zero
+func newEventLog(limit int) *eventLog {
+	return &eventLog{
+		limit: limit,
+	}
+}
+
+// append adds an event, dropping the oldest events once a quarter over the limit
+func (l *eventLog) append(event engine.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, event)
+	l.finished = l.finished || event.Type == engine.EventFinished
+	if l.notify != nil {
+		close(l.notify)
+		l.notify = nil
+	}
+
+	if l.limit <= 0 || len(l.events) <= l.limit+l.limit/4 {
+		return
+	}
+
+	dropped := len(l.events) - l.limit
+	l.events = append(l.events[:0], l.events[dropped:]...)
+	l.first += int64(dropped)
+}
+
+// next retrieves the offset the next event will get
+func (l *eventLog) next() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.first + int64(len(l.events))
+}
+
+// wait retrieves a channel closed once there are events from an offset on
+func (l *eventLog) wait(offset int64) <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if offset < l.first+int64(len(l.events)) {
+		ready := make(chan struct{})
+		close(ready)
+		return ready
+	}
+
+	if l.notify == nil {
+		l.notify = make(chan struct{})
+	}
+	return l.notify
+}
+
+// over checks if the simulation finished with no event from an offset on
+func (l *eventLog) over(offset int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.finished && offset >= l.first+int64(len(l.events))
+}
+
+// since retrieves up to limit events from an offset, with the offset of the first one returned
+// and the offset to continue from; the first offset is past the one asked for when events were dropped
+func (l *eventLog) since(offset int64, limit int) ([]engine.Event, int64, int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	next := l.first + int64(len(l.events))
+	if offset < l.first {
+		offset = l.first
+	}
+
+	if offset >= next {
+		return []engine.Event{}, next, next
+	}
+
+	events := l.events[offset-l.first:]
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+
+	result := make([]engine.Event, len(events))
+	copy(result, events)
+	return result, offset, offset + int64(len(result))
+}
+
+// EventPage Type definition, a page of the events of a simulation
+type EventPage struct {
+	// Offset of the first event of the page, past the offset asked for when older events were dropped
+	Offset int64 `json:"offset"`
+	// Offset to ask for the following page
+	Next int64 `json:"next"`
+	// Events of the page, in the order they happened
+	Events []engine.Event `json:"events"`
+}
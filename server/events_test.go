@@ -0,0 +1,65 @@
+package server
+
+import (
+	"testing"
+
+	"alien-invasion-cc/engine"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_eventLog(t *testing.T) {
+	log := newEventLog(4)
+	for step := uint(0); step < 5; step++ {
+		log.append(engine.Event{Step: step, Type: engine.EventMoved})
+	}
+
+	// within a quarter over the limit, nothing is dropped
+	events, first, next := log.since(0, 0)
+	require.Len(t, events, 5)
+	require.Equal(t, int64(0), first)
+	require.Equal(t, int64(5), next)
+
+	log.append(engine.Event{Step: 5, Type: engine.EventFinished})
+	require.Equal(t, int64(6), log.next())
+
+	// the oldest events were dropped, the offsets are kept
+	events, first, next = log.since(0, 2)
+	require.Equal(t, int64(2), first)
+	require.Equal(t, int64(4), next)
+	require.Equal(t, []engine.Event{{Step: 2, Type: engine.EventMoved}, {Step: 3, Type: engine.EventMoved}}, events)
+
+	events, first, next = log.since(5, 10)
+	require.Equal(t, []engine.Event{{Step: 5, Type: engine.EventFinished}}, events)
+	require.Equal(t, int64(5), first)
+	require.Equal(t, int64(6), next)
+
+	events, first, next = log.since(9, 10)
+	require.Empty(t, events)
+	require.Equal(t, int64(6), first)
+	require.Equal(t, int64(6), next)
+}
+
+func Test_eventLog_Wait(t *testing.T) {
+	log := newEventLog(0)
+	log.append(engine.Event{Type: engine.EventLanded})
+
+	// an event past the offset is already there
+	select {
+	case <-log.wait(0):
+	default:
+		require.Fail(t, "event not notified")
+	}
+
+	waiting := log.wait(1)
+	select {
+	case <-waiting:
+		require.Fail(t, "no event to notify")
+	default:
+	}
+	require.False(t, log.over(1))
+
+	log.append(engine.Event{Step: 1, Type: engine.EventFinished})
+	<-waiting
+	require.False(t, log.over(1))
+	require.True(t, log.over(2))
+}
@@ -0,0 +1,386 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"alien-invasion-cc/engine"
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+	"alien-invasion-cc/server/simulatorpb"
+)
+
+// simulatorService exposes the maps and simulations of a Server over gRPC
+type simulatorService struct {
+	simulatorpb.UnimplementedSimulatorServer
+
+	server *Server
+}
+
+// RegisterGRPC registers the Simulator gRPC service, sharing the maps and simulations of the REST API
+func (s *Server) RegisterGRPC(registrar grpc.ServiceRegistrar) {
+	simulatorpb.RegisterSimulatorServer(registrar, &simulatorService{server: s})
+}
+
+// Generate New gRPC Server serving the Simulator service
+func (s *Server) NewGRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(opts...)
+	s.RegisterGRPC(srv)
+	return srv
+}
+
+func (g *simulatorService) LoadEngine(ctx context.Context, request *simulatorpb.LoadEngineRequest) (*simulatorpb.SimulationState, error) {
+	m, err := g.loadMap(ctx, request)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	params := SimulationParams{
+		MapID:         m.ID,
+		Aliens:        uint(request.Aliens),
+		MaxMoves:      uint(request.MaxMoves),
+		Seed:          request.Seed,
+		RoadFights:    request.RoadFights,
+		TwoWay:        request.TwoWay,
+		Fragmentation: request.Fragmentation,
+	}
+
+	simulation, err := g.server.addSimulation(ctx, m, params)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return g.state(ctx, simulation)
+}
+
+// loadMap retrieves the uploaded map a simulation is created on, or validates the map given in the request
+func (g *simulatorService) loadMap(ctx context.Context, request *simulatorpb.LoadEngineRequest) (*Map, error) {
+	if mapID, ok := request.Map.(*simulatorpb.LoadEngineRequest_MapId); ok {
+		return g.server.lookupMap(mapID.MapId)
+	}
+
+	content := request.GetContent()
+	if len(content) == 0 {
+		return nil, ERR_INVALID_REQUEST
+	}
+
+	if int64(len(content)) > g.server.maxMapSize {
+		return nil, ERR_MAP_TOO_LARGE
+	}
+
+	format, err := mapfile.ParseFormat(request.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	directionProfile := request.Directions
+	if directionProfile == "" {
+		directionProfile = types.ClassicDirections.Name
+	}
+
+	directions, err := types.GetDirectionProfile(directionProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	// a map given in the request belongs to its simulation only, its free-form directions too, at most
+	// types.MaxFreeFormDirections of them
+	return newMap(ctx, "", "", format, directions, content)
+}
+
+func (g *simulatorService) HasNextMove(ctx context.Context, request *simulatorpb.SimulationRequest) (*simulatorpb.HasNextMoveResponse, error) {
+	simulation, err := g.server.lookupSimulation(request.SimulationId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	hasNextMove, err := simulation.HasNextMove(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &simulatorpb.HasNextMoveResponse{HasNextMove: hasNextMove}, nil
+}
+
+func (g *simulatorService) DoNextMove(ctx context.Context, request *simulatorpb.SimulationRequest) (*simulatorpb.SimulationState, error) {
+	return g.takeSteps(ctx, request.SimulationId, 1)
+}
+
+func (g *simulatorService) Run(ctx context.Context, request *simulatorpb.SimulationRequest) (*simulatorpb.SimulationState, error) {
+	return g.takeSteps(ctx, request.SimulationId, 0)
+}
+
+// takeSteps runs steps of a simulation and retrieves its state
+func (g *simulatorService) takeSteps(ctx context.Context, id string, count uint) (*simulatorpb.SimulationState, error) {
+	simulation, err := g.server.lookupSimulation(id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	err = g.server.takeSteps(ctx, simulation, count)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return g.state(ctx, simulation)
+}
+
+func (g *simulatorService) Finalize(ctx context.Context, request *simulatorpb.SimulationRequest) (*simulatorpb.FinalizeResponse, error) {
+	simulation, err := g.server.lookupSimulation(request.SimulationId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	results, err := simulation.Finalize(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &simulatorpb.FinalizeResponse{
+		State:  stateMessage(&results.SimulationState),
+		Report: results.Report,
+	}, nil
+}
+
+func (g *simulatorService) GetState(ctx context.Context, request *simulatorpb.SimulationRequest) (*simulatorpb.SimulationState, error) {
+	simulation, err := g.server.lookupSimulation(request.SimulationId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return g.state(ctx, simulation)
+}
+
+func (g *simulatorService) DeleteSimulation(ctx context.Context, request *simulatorpb.SimulationRequest) (*simulatorpb.DeleteSimulationResponse, error) {
+	err := g.server.removeSimulation(request.SimulationId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &simulatorpb.DeleteSimulationResponse{}, nil
+}
+
+func (g *simulatorService) ListCities(ctx context.Context, request *simulatorpb.SimulationRequest) (*simulatorpb.ListCitiesResponse, error) {
+	state, err := g.lookupState(ctx, request.SimulationId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	occupants := occupantsOf(state.Result)
+	cities := make([]*simulatorpb.City, 0, len(state.Result.Cities))
+	for _, city := range state.Result.Cities {
+		cities = append(cities, cityMessage(city, occupants[city.Name]))
+	}
+
+	return &simulatorpb.ListCitiesResponse{Cities: cities}, nil
+}
+
+func (g *simulatorService) GetCity(ctx context.Context, request *simulatorpb.GetCityRequest) (*simulatorpb.City, error) {
+	state, err := g.lookupState(ctx, request.SimulationId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	for _, city := range state.Result.Cities {
+		if city.Name == request.Name {
+			return cityMessage(city, occupantsOf(state.Result)[city.Name]), nil
+		}
+	}
+
+	return nil, statusError(types.ERR_UNKNOWN_CITY)
+}
+
+func (g *simulatorService) ListAliens(ctx context.Context, request *simulatorpb.SimulationRequest) (*simulatorpb.ListAliensResponse, error) {
+	state, err := g.lookupState(ctx, request.SimulationId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	aliens := make([]*simulatorpb.Alien, 0, len(state.Result.Aliens))
+	for _, alien := range state.Result.Aliens {
+		aliens = append(aliens, &simulatorpb.Alien{
+			Id:          int32(alien.ID),
+			City:        alien.City,
+			Trapped:     alien.Trapped,
+			Road:        alien.Road,
+			Destination: alien.Destination,
+			StepsLeft:   uint32(alien.StepsLeft),
+		})
+	}
+
+	return &simulatorpb.ListAliensResponse{Aliens: aliens}, nil
+}
+
+func (g *simulatorService) Watch(request *simulatorpb.WatchRequest, stream simulatorpb.Simulator_WatchServer) error {
+	simulation, err := g.server.lookupSimulation(request.SimulationId)
+	if err != nil {
+		return statusError(err)
+	}
+
+	if request.Offset < 0 {
+		return statusError(ERR_INVALID_REQUEST)
+	}
+
+	ctx, cancel := g.server.streamContext(stream.Context())
+	defer cancel()
+
+	err = simulation.follow(ctx, request.Offset, &grpcSink{stream: stream}, g.server.keepAlive)
+	if err != nil {
+		return statusError(err)
+	}
+	return nil
+}
+
+// lookupState retrieves the state of a simulation by ID
+func (g *simulatorService) lookupState(ctx context.Context, id string) (*SimulationState, error) {
+	simulation, err := g.server.lookupSimulation(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return simulation.State(ctx)
+}
+
+// state retrieves the state of a simulation as a message
+func (g *simulatorService) state(ctx context.Context, simulation *Simulation) (*simulatorpb.SimulationState, error) {
+	state, err := simulation.State(ctx)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return stateMessage(state), nil
+}
+
+// grpcSink writes events to a Watch stream; gRPC keeps idle connections alive itself
+type grpcSink struct {
+	stream simulatorpb.Simulator_WatchServer
+}
+
+func (k *grpcSink) send(offset int64, event engine.Event) error {
+	aliens := make([]int32, 0, len(event.Aliens))
+	for _, alienID := range event.Aliens {
+		aliens = append(aliens, int32(alienID))
+	}
+
+	return k.stream.Send(&simulatorpb.WatchResponse{
+		Offset: offset,
+		Event: &simulatorpb.Event{
+			Step:   uint32(event.Step),
+			Type:   string(event.Type),
+			Aliens: aliens,
+			City:   event.City,
+			From:   event.From,
+			Road:   event.Road,
+			Reason: string(event.Reason),
+		},
+	})
+}
+
+func (k *grpcSink) dropped(offset, count int64) error {
+	return k.stream.Send(&simulatorpb.WatchResponse{Offset: offset, Dropped: count})
+}
+
+func (k *grpcSink) keepAlive() error {
+	return nil
+}
+
+func (k *grpcSink) flush() error {
+	return nil
+}
+
+// occupantsOf retrieves the ID of the alien residing in each city
+func occupantsOf(result *engine.Result) map[string]int {
+	occupants := make(map[string]int)
+	for _, alien := range result.Aliens {
+		if alien.City != "" && !alien.Trapped && alien.Road == "" {
+			occupants[alien.City] = alien.ID
+		}
+	}
+	return occupants
+}
+
+// cityMessage converts a city of a Result to a message
+func cityMessage(city engine.CityState, alienID int) *simulatorpb.City {
+	return &simulatorpb.City{
+		Name:      city.Name,
+		Destroyed: city.Destroyed,
+		Links:     city.Links,
+		AlienId:   int32(alienID),
+	}
+}
+
+// stateMessage converts the state of a simulation to a message
+func stateMessage(state *SimulationState) *simulatorpb.SimulationState {
+	return &simulatorpb.SimulationState{
+		Id: state.ID,
+		Params: &simulatorpb.SimulationParams{
+			MapId:         state.Params.MapID,
+			Aliens:        uint32(state.Params.Aliens),
+			MaxMoves:      uint32(state.Params.MaxMoves),
+			Seed:          *state.Params.Seed,
+			RoadFights:    state.Params.RoadFights,
+			TwoWay:        state.Params.TwoWay,
+			Fragmentation: state.Params.Fragmentation,
+		},
+		Running:   state.Running,
+		NextEvent: state.NextEvent,
+		Result: &simulatorpb.Result{
+			Steps:           uint32(state.Result.Steps),
+			MaxMoves:        uint32(state.Result.MaxMoves),
+			Finished:        state.Result.Finished,
+			RemainingCities: int32(state.Result.RemainingCities),
+			DestroyedCities: int32(state.Result.DestroyedCities),
+			Termination:     string(state.Result.Termination),
+			Fragmentation:   fragmentationMessage(state.Result.Fragmentation),
+		},
+	}
+}
+
+// fragmentationMessage converts the fragmentation of a Result to a message, nil when not reported
+func fragmentationMessage(fragmentation *engine.Fragmentation) *simulatorpb.Fragmentation {
+	if fragmentation == nil {
+		return nil
+	}
+
+	return &simulatorpb.Fragmentation{
+		ComponentsBefore:  int32(fragmentation.ComponentsBefore),
+		ComponentsAfter:   int32(fragmentation.ComponentsAfter),
+		LargestBefore:     int32(fragmentation.LargestBefore),
+		LargestAfter:      int32(fragmentation.LargestAfter),
+		DisconnectedPairs: fragmentation.DisconnectedPairs,
+		IsolatedCities:    fragmentation.IsolatedCities,
+	}
+}
+
+// statusError converts an error to a gRPC status, with the code matching its HTTP status
+func statusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Internal
+	switch statusOf(err) {
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusMethodNotAllowed:
+		code = codes.Unimplemented
+	case http.StatusTooManyRequests, http.StatusRequestEntityTooLarge:
+		code = codes.ResourceExhausted
+	case http.StatusConflict:
+		code = codes.FailedPrecondition
+	case http.StatusGone:
+		code = codes.Aborted
+	case http.StatusGatewayTimeout:
+		code = codes.DeadlineExceeded
+	case http.StatusServiceUnavailable:
+		code = codes.Canceled
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		code = codes.InvalidArgument
+	}
+
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"alien-invasion-cc/engine"
+	"alien-invasion-cc/engine/types"
+	"alien-invasion-cc/server/simulatorpb"
+)
+
+// dialBufconn serves the Simulator service of a server on an in-memory listener, returns a client to it
+func dialBufconn(t *testing.T, s *Server) simulatorpb.SimulatorClient {
+	listener := bufconn.Listen(1024 * 1024)
+	srv := s.NewGRPCServer()
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return simulatorpb.NewSimulatorClient(conn)
+}
+
+func Test_GRPC_Simulation(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer()
+	client := dialBufconn(t, s)
+
+	content, err := os.ReadFile("../test_data/test_map2")
+	require.NoError(t, err)
+
+	seed := int64(42)
+	created, err := client.LoadEngine(ctx, &simulatorpb.LoadEngineRequest{
+		Map:      &simulatorpb.LoadEngineRequest_Content{Content: content},
+		Aliens:   50,
+		MaxMoves: 200,
+		Seed:     &seed,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(42), created.Params.Seed)
+	require.Equal(t, uint32(0), created.Result.Steps)
+	require.Empty(t, created.Params.MapId)
+	id := &simulatorpb.SimulationRequest{SimulationId: created.Id}
+
+	hasNextMove, err := client.HasNextMove(ctx, id)
+	require.NoError(t, err)
+	require.True(t, hasNextMove.HasNextMove)
+
+	stepped, err := client.DoNextMove(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), stepped.Result.Steps)
+
+	// the world is queried as it stands
+	cities, err := client.ListCities(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, cities.Cities, 625)
+
+	aliens, err := client.ListAliens(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, aliens.Aliens, 50)
+
+	for _, alien := range aliens.Aliens {
+		if alien.Trapped || alien.Road != "" {
+			continue
+		}
+
+		city, err := client.GetCity(ctx, &simulatorpb.GetCityRequest{SimulationId: created.Id, Name: alien.City})
+		require.NoError(t, err)
+		require.Equal(t, alien.Id, city.AlienId)
+		require.NotEmpty(t, city.Links)
+	}
+
+	// the events are watched up to the end of the run
+	watch, err := client.Watch(ctx, &simulatorpb.WatchRequest{SimulationId: created.Id})
+	require.NoError(t, err)
+
+	finished, err := client.Run(ctx, id)
+	require.NoError(t, err)
+	require.True(t, finished.Result.Finished)
+
+	events := []*simulatorpb.Event{}
+	for {
+		message, err := watch.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, int64(len(events)), message.Offset)
+		events = append(events, message.Event)
+	}
+	require.Len(t, events, int(finished.NextEvent))
+	require.Equal(t, "finished", events[len(events)-1].Type)
+	require.NotEmpty(t, finished.Result.Termination)
+	require.Equal(t, finished.Result.Termination, events[len(events)-1].Reason)
+
+	// the simulation is the one of the REST API
+	simulation, err := s.lookupSimulation(created.Id)
+	require.NoError(t, err)
+	page, _, _ := simulation.events.since(0, 0)
+	require.Equal(t, string(page[1].Type), events[1].Type)
+	require.Equal(t, page[1].City, events[1].City)
+
+	final, err := client.Finalize(ctx, id)
+	require.NoError(t, err)
+	require.Contains(t, final.Report, "Simulation Finished")
+	require.Equal(t, finished.Result, final.State.Result)
+
+	_, err = client.DeleteSimulation(ctx, id)
+	require.NoError(t, err)
+	_, err = client.GetState(ctx, id)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_GRPC_Fragmentation(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer()
+	client := dialBufconn(t, s)
+	m := uploadMap(t, s, "../test_data/test_map2")
+
+	seed := int64(3)
+	created, err := client.LoadEngine(ctx, &simulatorpb.LoadEngineRequest{Map: &simulatorpb.LoadEngineRequest_MapId{MapId: m.ID}, Aliens: 300, MaxMoves: 100, Seed: &seed, Fragmentation: true})
+	require.NoError(t, err)
+	require.True(t, created.Params.Fragmentation)
+	require.Nil(t, created.Result.Fragmentation)
+
+	finished, err := client.Run(ctx, &simulatorpb.SimulationRequest{SimulationId: created.Id})
+	require.NoError(t, err)
+	require.NotNil(t, finished.Result.Fragmentation)
+	require.Equal(t, int32(625), finished.Result.Fragmentation.LargestBefore)
+
+	// the result is the one of the REST API
+	simulation, err := s.lookupSimulation(created.Id)
+	require.NoError(t, err)
+	results, err := simulation.Results(ctx)
+	require.NoError(t, err)
+	require.Equal(t, results.Result.Fragmentation.DisconnectedPairs, finished.Result.Fragmentation.DisconnectedPairs)
+	require.Equal(t, results.Result.Fragmentation.IsolatedCities, finished.Result.Fragmentation.IsolatedCities)
+
+	final, err := client.Finalize(ctx, &simulatorpb.SimulationRequest{SimulationId: created.Id})
+	require.NoError(t, err)
+	require.Contains(t, final.Report, "Disconnected Pairs: ")
+}
+
+func Test_GRPC_FreeFormMaps(t *testing.T) {
+	ctx := context.Background()
+	client := dialBufconn(t, NewServer())
+
+	// a map given in the request has room for its own free-form directions, released with its simulation
+	for _, prefix := range []string{"east", "west"} {
+		created, err := client.LoadEngine(ctx, &simulatorpb.LoadEngineRequest{Map: &simulatorpb.LoadEngineRequest_Content{Content: freeFormMap(prefix, types.MaxFreeFormDirections).Bytes()}, Directions: "free"})
+		require.NoError(t, err)
+
+		_, err = client.DeleteSimulation(ctx, &simulatorpb.SimulationRequest{SimulationId: created.Id})
+		require.NoError(t, err)
+	}
+
+	_, err := client.LoadEngine(ctx, &simulatorpb.LoadEngineRequest{Map: &simulatorpb.LoadEngineRequest_Content{Content: freeFormMap("north", types.MaxFreeFormDirections+1).Bytes()}, Directions: "free"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), types.ERR_TOO_MANY_DIRECTIONS.Error())
+}
+
+func Test_GRPC_Errors(t *testing.T) {
+	ctx := context.Background()
+	s := NewServer(WithMaxSimulations(1))
+	client := dialBufconn(t, s)
+
+	m := uploadMap(t, s, "../test_data/test_map")
+
+	_, err := client.LoadEngine(ctx, &simulatorpb.LoadEngineRequest{Map: &simulatorpb.LoadEngineRequest_MapId{MapId: "nope"}})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.LoadEngine(ctx, &simulatorpb.LoadEngineRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.LoadEngine(ctx, &simulatorpb.LoadEngineRequest{Map: &simulatorpb.LoadEngineRequest_Content{Content: []byte("Foo north=Foo\n")}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.LoadEngine(ctx, &simulatorpb.LoadEngineRequest{Map: &simulatorpb.LoadEngineRequest_Content{Content: []byte("Foo\n")}, Directions: "spiral"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// a simulation created on an uploaded map finalized early is over; the seed is fixed, some placements leaving
+	// no two aliens able to meet, which ends the simulation with no_fights instead
+	seed := int64(1)
+	created, err := client.LoadEngine(ctx, &simulatorpb.LoadEngineRequest{Map: &simulatorpb.LoadEngineRequest_MapId{MapId: m.ID}, Seed: &seed})
+	require.NoError(t, err)
+	require.Equal(t, m.ID, created.Params.MapId)
+	require.Equal(t, uint32(DefaultAliens), created.Params.Aliens)
+	id := &simulatorpb.SimulationRequest{SimulationId: created.Id}
+
+	_, err = client.LoadEngine(ctx, &simulatorpb.LoadEngineRequest{Map: &simulatorpb.LoadEngineRequest_MapId{MapId: m.ID}})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	final, err := client.Finalize(ctx, id)
+	require.NoError(t, err)
+	require.True(t, final.State.Result.Finished)
+	require.Equal(t, uint32(0), final.State.Result.Steps)
+	require.Equal(t, string(engine.TerminationStopped), final.State.Result.Termination)
+
+	hasNextMove, err := client.HasNextMove(ctx, id)
+	require.NoError(t, err)
+	require.False(t, hasNextMove.HasNextMove)
+
+	_, err = client.GetCity(ctx, &simulatorpb.GetCityRequest{SimulationId: created.Id, Name: "Atlantis"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	watch, err := client.Watch(ctx, &simulatorpb.WatchRequest{SimulationId: created.Id, Offset: -1})
+	require.NoError(t, err)
+	_, err = watch.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Run(ctx, &simulatorpb.SimulationRequest{SimulationId: "nope"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"alien-invasion-cc/engine"
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+)
+
+// Map Type definition, an uploaded map simulations are created from
+type Map struct {
+	// Map ID
+	ID string `json:"id"`
+	// Name given at upload
+	Name string `json:"name,omitempty"`
+	// Format of the map
+	Format mapfile.Format `json:"format"`
+	// Direction profile the map uses
+	Directions string `json:"directions"`
+	// Number of cities
+	Cities int `json:"cities"`
+	// Number of roads
+	Roads int `json:"roads"`
+	// Size of the map in bytes
+	Size int `json:"size"`
+
+	content []byte
+
+	directions *types.DirectionProfile
+}
+
+// newMap validates an uploaded map by loading it
+func newMap(ctx context.Context, id, name string, format mapfile.Format, directions *types.DirectionProfile, content []byte) (*Map, error) {
+	if format == mapfile.FormatAuto || format == "" {
+		detected, _, err := mapfile.SniffFormat(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		format = detected
+	}
+
+	e := engine.NewEngine(0, 0, bytes.NewReader(content), io.Discard, engine.WithMapFormat(format), engine.WithDirectionProfile(directions))
+	err := e.LoadEngine(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cities, err := e.World().GetCities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roads, err := e.World().GetRoads(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Map{
+		ID:         id,
+		Name:       name,
+		Format:     format,
+		Directions: directions.Name,
+		Cities:     len(cities),
+		Roads:      len(roads),
+		Size:       len(content),
+		content:    content,
+		directions: directions,
+	}, nil
+}
+
+// open retrieves a reader over the map content
+func (m *Map) open() io.Reader {
+	return bytes.NewReader(m.content)
+}
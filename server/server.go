@@ -0,0 +1,690 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"alien-invasion-cc/engine"
+	"alien-invasion-cc/engine/mapfile"
+	"alien-invasion-cc/engine/types"
+	"alien-invasion-cc/metrics"
+)
+
+const (
+	// DefaultMaxSimulations is the number of simulations a server holds at once
+	DefaultMaxSimulations = 100
+	// DefaultMaxMaps is the number of maps a server holds at once
+	DefaultMaxMaps = 100
+	// DefaultMaxMapSize is the size in bytes of the largest map a server accepts
+	DefaultMaxMapSize = 64 * 1024 * 1024
+	// maxRequestSize is the size in bytes of the largest JSON request body accepted
+	maxRequestSize = 1024 * 1024
+)
+
+// Server exposes maps and simulations over a REST API
+type Server struct {
+	// mu guards the maps, the simulations and the IDs
+	mu sync.Mutex
+
+	maps map[string]*Map
+
+	simulations map[string]*Simulation
+
+	lastID int
+
+	maxSimulations int
+
+	maxMaps int
+
+	maxMapSize int64
+
+	maxEvents int
+
+	// running holds a token per simulation running steps
+	running chan struct{}
+
+	keepAlive time.Duration
+
+	allowedOrigins []string
+
+	registry *metrics.Registry
+
+	metrics *engine.Metrics
+
+	// streamsClosed is closed to end the event streams
+	streamsClosed chan struct{}
+
+	closeStreams sync.Once
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithMaxSimulations sets the number of simulations held at once
+func WithMaxSimulations(n int) Option {
+	return func(s *Server) {
+		s.maxSimulations = n
+	}
+}
+
+// WithMaxMaps sets the number of maps held at once
+func WithMaxMaps(n int) Option {
+	return func(s *Server) {
+		s.maxMaps = n
+	}
+}
+
+// WithMaxRunning sets the number of simulations running steps at once, the others wait for their turn
+func WithMaxRunning(n int) Option {
+	return func(s *Server) {
+		if n > 0 {
+			s.running = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithMaxMapSize sets the size in bytes of the largest map accepted
+func WithMaxMapSize(size int64) Option {
+	return func(s *Server) {
+		s.maxMapSize = size
+	}
+}
+
+// WithMaxEvents sets the number of events kept per simulation, the older ones being dropped
+func WithMaxEvents(n int) Option {
+	return func(s *Server) {
+		s.maxEvents = n
+	}
+}
+
+// WithKeepAlive sets the interval between two keep-alive messages of an idle event stream
+func WithKeepAlive(interval time.Duration) Option {
+	return func(s *Server) {
+		if interval > 0 {
+			s.keepAlive = interval
+		}
+	}
+}
+
+// WithAllowedOrigins sets the origins WebSocket clients may connect from besides the server's own, "*" allows any
+func WithAllowedOrigins(origins ...string) Option {
+	return func(s *Server) {
+		s.allowedOrigins = origins
+	}
+}
+
+// WithMetrics reports the steps of the simulations to a registry, served on /metrics in the Prometheus
+// text format and on /debug/vars through expvar
+func WithMetrics(registry *metrics.Registry) Option {
+	return func(s *Server) {
+		s.registry = registry
+		s.metrics = engine.NewMetrics(registry)
+	}
+}
+
+// Generate New Server
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		maps:           make(map[string]*Map),
+		simulations:    make(map[string]*Simulation),
+		maxSimulations: DefaultMaxSimulations,
+		maxMaps:        DefaultMaxMaps,
+		maxMapSize:     DefaultMaxMapSize,
+		maxEvents:      DefaultMaxEvents,
+		keepAlive:      DefaultKeepAlive,
+		streamsClosed:  make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// ServeHTTP routes a request
+//
+//	POST   /maps                        upload a map, ?name=&format=&directions=
+//	GET    /maps                        list the maps
+//	GET    /maps/{id}                   describe a map
+//	DELETE /maps/{id}                   delete a map
+//	POST   /simulations                 create a simulation from SimulationParams
+//	GET    /simulations                 list the simulations
+//	GET    /simulations/{id}            fetch the state of a simulation
+//	DELETE /simulations/{id}            delete a simulation, stopping its steps
+//	POST   /simulations/{id}/step       run steps, ?count= (1 by default)
+//	POST   /simulations/{id}/run        run the simulation to its end, ?timeout= bounds the run
+//	GET    /simulations/{id}/events     list events, ?offset=&limit=
+//	GET    /simulations/{id}/results    download the outcome of a finished simulation
+//	GET    /simulations/{id}/stream     stream events as Server-Sent Events, from Last-Event-ID or ?offset=
+//	GET    /simulations/{id}/ws         stream events over a WebSocket, from ?offset=
+//	GET    /metrics                     metrics in the Prometheus text format, WithMetrics
+//	GET    /debug/vars                  expvar variables, WithMetrics
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(path) == 1 && path[0] == "maps":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodGet:  s.listMaps,
+			http.MethodPost: s.uploadMap,
+		})
+	case len(path) == 2 && path[0] == "maps":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodGet:    func(w http.ResponseWriter, r *http.Request) { s.getMap(w, r, path[1]) },
+			http.MethodDelete: func(w http.ResponseWriter, r *http.Request) { s.deleteMap(w, r, path[1]) },
+		})
+	case len(path) == 1 && path[0] == "simulations":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodGet:  s.listSimulations,
+			http.MethodPost: s.createSimulation,
+		})
+	case len(path) == 2 && path[0] == "simulations":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodGet:    func(w http.ResponseWriter, r *http.Request) { s.getSimulation(w, r, path[1]) },
+			http.MethodDelete: func(w http.ResponseWriter, r *http.Request) { s.deleteSimulation(w, r, path[1]) },
+		})
+	case len(path) == 3 && path[0] == "simulations":
+		s.routeSimulation(w, r, path[1], path[2])
+	case len(path) == 1 && path[0] == "metrics" && s.registry != nil:
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodGet: s.registry.Handler().ServeHTTP,
+		})
+	case len(path) == 2 && path[0] == "debug" && path[1] == "vars" && s.registry != nil:
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodGet: expvar.Handler().ServeHTTP,
+		})
+	default:
+		writeError(w, ERR_NOT_FOUND)
+	}
+}
+
+// routeSimulation routes a request on a simulation resource
+func (s *Server) routeSimulation(w http.ResponseWriter, r *http.Request, id, resource string) {
+	switch resource {
+	case "step":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodPost: func(w http.ResponseWriter, r *http.Request) { s.stepSimulation(w, r, id) },
+		})
+	case "run":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodPost: func(w http.ResponseWriter, r *http.Request) { s.runSimulation(w, r, id) },
+		})
+	case "events":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodGet: func(w http.ResponseWriter, r *http.Request) { s.listEvents(w, r, id) },
+		})
+	case "results":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodGet: func(w http.ResponseWriter, r *http.Request) { s.getResults(w, r, id) },
+		})
+	case "stream":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodGet: func(w http.ResponseWriter, r *http.Request) { s.streamEvents(w, r, id) },
+		})
+	case "ws":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodGet: func(w http.ResponseWriter, r *http.Request) { s.watchEvents(w, r, id) },
+		})
+	default:
+		writeError(w, ERR_NOT_FOUND)
+	}
+}
+
+// route calls the handler of the request method
+func (s *Server) route(w http.ResponseWriter, r *http.Request, handlers map[string]http.HandlerFunc) {
+	handler, found := handlers[r.Method]
+	if !found {
+		writeError(w, ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	handler(w, r)
+}
+
+// nextID retrieves a new map or simulation ID, the lock being held
+func (s *Server) nextID() string {
+	s.lastID++
+	return strconv.Itoa(s.lastID)
+}
+
+func (s *Server) uploadMap(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format, err := mapfile.ParseFormat(query.Get("format"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	directionProfile := query.Get("directions")
+	if directionProfile == "" {
+		directionProfile = types.ClassicDirections.Name
+	}
+
+	directions, err := types.GetDirectionProfile(directionProfile)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	content, err := io.ReadAll(io.LimitReader(r.Body, s.maxMapSize+1))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if int64(len(content)) > s.maxMapSize {
+		writeError(w, ERR_MAP_TOO_LARGE)
+		return
+	}
+
+	m, err := s.addMap(r.Context(), query.Get("name"), format, directions, content)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, m)
+}
+
+func (s *Server) listMaps(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	maps := make([]*Map, 0, len(s.maps))
+	for _, m := range s.maps {
+		if m != nil {
+			maps = append(maps, m)
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(maps, func(i, j int) bool { return lessID(maps[i].ID, maps[j].ID) })
+	writeJSON(w, http.StatusOK, maps)
+}
+
+func (s *Server) getMap(w http.ResponseWriter, r *http.Request, id string) {
+	m, err := s.lookupMap(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, m)
+}
+
+func (s *Server) deleteMap(w http.ResponseWriter, r *http.Request, id string) {
+	s.mu.Lock()
+	m := s.maps[id]
+	if m != nil {
+		delete(s.maps, id)
+	}
+	s.mu.Unlock()
+
+	if m == nil {
+		writeError(w, ERR_UNKNOWN_MAP)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// addMap validates a map and adds it to the maps simulations are created from, within the limit of maps
+func (s *Server) addMap(ctx context.Context, name string, format mapfile.Format, directions *types.DirectionProfile, content []byte) (*Map, error) {
+	// the slot is booked before loading, so the limit holds under concurrent uploads
+	s.mu.Lock()
+	if s.maxMaps > 0 && len(s.maps) >= s.maxMaps {
+		s.mu.Unlock()
+		return nil, ERR_TOO_MANY_MAPS
+	}
+	id := s.nextID()
+	s.maps[id] = nil
+	s.mu.Unlock()
+
+	m, err := newMap(ctx, id, name, format, directions, content)
+
+	s.mu.Lock()
+	if err != nil {
+		delete(s.maps, id)
+	} else {
+		s.maps[id] = m
+	}
+	s.mu.Unlock()
+
+	return m, err
+}
+
+// lookupMap retrieves a map by ID
+func (s *Server) lookupMap(id string) (*Map, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := s.maps[id]
+	if m == nil {
+		return nil, ERR_UNKNOWN_MAP
+	}
+	return m, nil
+}
+
+func (s *Server) createSimulation(w http.ResponseWriter, r *http.Request) {
+	params := SimulationParams{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&params)
+	if err != nil {
+		writeError(w, ERR_INVALID_REQUEST)
+		return
+	}
+
+	m, err := s.lookupMap(params.MapID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	simulation, err := s.addSimulation(r.Context(), m, params)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.writeState(w, r, http.StatusCreated, simulation)
+}
+
+// addSimulation creates a simulation on a map, within the limit of simulations
+func (s *Server) addSimulation(ctx context.Context, m *Map, params SimulationParams) (*Simulation, error) {
+	// the slot is booked before loading, so the limit holds under concurrent creations
+	s.mu.Lock()
+	if s.maxSimulations > 0 && len(s.simulations) >= s.maxSimulations {
+		s.mu.Unlock()
+		return nil, ERR_TOO_MANY_SIMULATIONS
+	}
+	id := s.nextID()
+	s.simulations[id] = nil
+	s.mu.Unlock()
+
+	simulation, err := newSimulation(ctx, id, m, params, s.maxEvents, s.metrics)
+
+	s.mu.Lock()
+	if err != nil {
+		delete(s.simulations, id)
+	} else {
+		s.simulations[id] = simulation
+	}
+	s.mu.Unlock()
+
+	return simulation, err
+}
+
+func (s *Server) listSimulations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	simulations := make([]*Simulation, 0, len(s.simulations))
+	for _, simulation := range s.simulations {
+		if simulation != nil {
+			simulations = append(simulations, simulation)
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(simulations, func(i, j int) bool { return lessID(simulations[i].ID, simulations[j].ID) })
+
+	states := make([]*SimulationState, 0, len(simulations))
+	for _, simulation := range simulations {
+		state, err := simulation.State(r.Context())
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		states = append(states, state)
+	}
+
+	writeJSON(w, http.StatusOK, states)
+}
+
+func (s *Server) getSimulation(w http.ResponseWriter, r *http.Request, id string) {
+	simulation, err := s.lookupSimulation(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.writeState(w, r, http.StatusOK, simulation)
+}
+
+func (s *Server) deleteSimulation(w http.ResponseWriter, r *http.Request, id string) {
+	err := s.removeSimulation(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// removeSimulation deletes a simulation, stopping its steps and streams
+func (s *Server) removeSimulation(id string) error {
+	s.mu.Lock()
+	simulation := s.simulations[id]
+	if simulation != nil {
+		delete(s.simulations, id)
+	}
+	s.mu.Unlock()
+
+	if simulation == nil {
+		return ERR_UNKNOWN_SIMULATION
+	}
+
+	simulation.cancel()
+	return nil
+}
+
+func (s *Server) stepSimulation(w http.ResponseWriter, r *http.Request, id string) {
+	count := uint64(1)
+	if value := r.URL.Query().Get("count"); value != "" {
+		var err error
+		count, err = strconv.ParseUint(value, 10, 32)
+		if err != nil || count == 0 {
+			writeError(w, ERR_INVALID_REQUEST)
+			return
+		}
+	}
+
+	s.runSteps(w, r, id, uint(count))
+}
+
+func (s *Server) runSimulation(w http.ResponseWriter, r *http.Request, id string) {
+	s.runSteps(w, r, id, 0)
+}
+
+// runSteps runs steps of a simulation and writes its state, a run stopped by its ?timeout= is not an error
+func (s *Server) runSteps(w http.ResponseWriter, r *http.Request, id string, count uint) {
+	simulation, err := s.lookupSimulation(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+	if value := r.URL.Query().Get("timeout"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			writeError(w, ERR_INVALID_REQUEST)
+			return
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err = s.takeSteps(ctx, simulation, count)
+	if err != nil && !(errors.Is(err, context.DeadlineExceeded) && r.Context().Err() == nil) {
+		writeError(w, err)
+		return
+	}
+
+	s.writeState(w, r, http.StatusOK, simulation)
+}
+
+// takeSteps runs steps of a simulation once its turn comes within the limit of running simulations
+func (s *Server) takeSteps(ctx context.Context, simulation *Simulation, count uint) error {
+	if s.running != nil {
+		select {
+		case s.running <- struct{}{}:
+			defer func() { <-s.running }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return simulation.Step(ctx, count)
+}
+
+func (s *Server) listEvents(w http.ResponseWriter, r *http.Request, id string) {
+	simulation, err := s.lookupSimulation(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	offset, limit := int64(0), 0
+	if value := query.Get("offset"); value != "" {
+		offset, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || offset < 0 {
+			writeError(w, ERR_INVALID_REQUEST)
+			return
+		}
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			writeError(w, ERR_INVALID_REQUEST)
+			return
+		}
+	}
+
+	events, first, next := simulation.events.since(offset, limit)
+	writeJSON(w, http.StatusOK, EventPage{
+		Offset: first,
+		Next:   next,
+		Events: events,
+	})
+}
+
+func (s *Server) getResults(w http.ResponseWriter, r *http.Request, id string) {
+	simulation, err := s.lookupSimulation(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	results, err := simulation.Results(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", "attachment; filename=\"simulation-"+id+".json\"")
+	writeJSON(w, http.StatusOK, results)
+}
+
+// lookupSimulation retrieves a simulation by ID
+func (s *Server) lookupSimulation(id string) (*Simulation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	simulation := s.simulations[id]
+	if simulation == nil {
+		return nil, ERR_UNKNOWN_SIMULATION
+	}
+	return simulation, nil
+}
+
+// writeState writes the state of a simulation
+func (s *Server) writeState(w http.ResponseWriter, r *http.Request, status int, simulation *Simulation) {
+	state, err := simulation.State(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, status, state)
+}
+
+// lessID orders the IDs numerically
+func lessID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// ErrorResponse Type definition, the body of a failed request
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	err := json.NewEncoder(w).Encode(body)
+	if err != nil {
+		log.Warnf("writing the response: %v", err)
+	}
+}
+
+// writeError writes an error response, with the status matching the error
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusOf(err), ErrorResponse{Error: err.Error()})
+}
+
+// statusOf maps an error to an HTTP status
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, ERR_NOT_FOUND), errors.Is(err, ERR_UNKNOWN_MAP), errors.Is(err, ERR_UNKNOWN_SIMULATION),
+		errors.Is(err, types.ERR_UNKNOWN_CITY):
+		return http.StatusNotFound
+	case errors.Is(err, ERR_METHOD_NOT_ALLOWED):
+		return http.StatusMethodNotAllowed
+	case errors.Is(err, ERR_TOO_MANY_SIMULATIONS), errors.Is(err, ERR_TOO_MANY_MAPS):
+		return http.StatusTooManyRequests
+	case errors.Is(err, ERR_SIMULATION_BUSY), errors.Is(err, ERR_SIMULATION_NOT_FINISHED):
+		return http.StatusConflict
+	case errors.Is(err, ERR_SIMULATION_DELETED):
+		return http.StatusGone
+	case errors.Is(err, ERR_MAP_TOO_LARGE):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ERR_INVALID_REQUEST),
+		errors.Is(err, types.ERR_UNKNOWN_MAP_FORMAT),
+		errors.Is(err, types.ERR_UNKNOWN_DIRECTION_PROFILE):
+		return http.StatusBadRequest
+	case errors.Is(err, types.ERR_PARSE_CITY_DEFINITION),
+		errors.Is(err, types.ERR_LINE_TOO_LONG),
+		errors.Is(err, types.ERR_EMPTY_CITY_NAME),
+		errors.Is(err, types.ERR_LINK_SAME_CITY),
+		errors.Is(err, types.ERR_ALREADY_EXISTS_LINK),
+		errors.Is(err, types.ERR_INVALID_ROAD_LENGTH),
+		errors.Is(err, types.ERR_ROAD_LENGTH_MISMATCH),
+		errors.Is(err, types.ERR_UNKNOWN_DIRECTION),
+		errors.Is(err, types.ERR_TOO_MANY_DIRECTIONS):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
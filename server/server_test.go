@@ -0,0 +1,291 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"alien-invasion-cc/engine"
+	"alien-invasion-cc/engine/types"
+	"alien-invasion-cc/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+// call sends a request to the server, decodes the JSON response into out and returns the status
+func call(t *testing.T, handler http.Handler, method, target string, body io.Reader, out interface{}) int {
+	request := httptest.NewRequest(method, target, body)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	if out != nil && recorder.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), out), recorder.Body.String())
+	}
+	return recorder.Code
+}
+
+// uploadMap uploads a test_data map
+func uploadMap(t *testing.T, handler http.Handler, path string) *Map {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	m := &Map{}
+	status := call(t, handler, http.MethodPost, "/maps?name=test", bytes.NewReader(content), m)
+	require.Equal(t, http.StatusCreated, status)
+	return m
+}
+
+// createSimulation creates a simulation, returns its state
+func createSimulation(t *testing.T, handler http.Handler, params string) *SimulationState {
+	state := &SimulationState{}
+	status := call(t, handler, http.MethodPost, "/simulations", strings.NewReader(params), state)
+	require.Equal(t, http.StatusCreated, status)
+	return state
+}
+
+// freeFormMap writes a map of one city per free-form direction, every road named after its city
+func freeFormMap(prefix string, roads int) *bytes.Buffer {
+	lines := &bytes.Buffer{}
+	for i := 0; i < roads; i++ {
+		fmt.Fprintf(lines, "%s%d %s%d=Hub\n", prefix, i, prefix, i)
+	}
+	return lines
+}
+
+func Test_Server_Maps(t *testing.T) {
+	s := NewServer()
+
+	m := uploadMap(t, s, "../test_data/test_map2")
+	require.Equal(t, "test", m.Name)
+	require.Equal(t, "classic", string(m.Format))
+	require.Equal(t, 625, m.Cities)
+	require.NotZero(t, m.Roads)
+
+	structured := uploadMap(t, s, "../test_data/test_map.json")
+	require.Equal(t, "json", string(structured.Format))
+
+	var maps []Map
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, "/maps", nil, &maps))
+	require.Len(t, maps, 2)
+	require.Equal(t, m.ID, maps[0].ID)
+
+	got := &Map{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, "/maps/"+m.ID, nil, got))
+	require.Equal(t, m, got)
+
+	require.Equal(t, http.StatusNoContent, call(t, s, http.MethodDelete, "/maps/"+structured.ID, nil, nil))
+	require.Equal(t, http.StatusNotFound, call(t, s, http.MethodGet, "/maps/"+structured.ID, nil, nil))
+
+	// invalid maps are rejected
+	failure := &ErrorResponse{}
+	require.Equal(t, http.StatusUnprocessableEntity, call(t, s, http.MethodPost, "/maps", strings.NewReader("Foo north=Foo\n"), failure))
+	require.Equal(t, "no possible link between same city", failure.Error)
+	require.Equal(t, http.StatusBadRequest, call(t, s, http.MethodPost, "/maps?directions=spiral", strings.NewReader("Foo\n"), nil))
+
+	small := NewServer(WithMaxMapSize(10))
+	require.Equal(t, http.StatusRequestEntityTooLarge, call(t, small, http.MethodPost, "/maps", strings.NewReader("Foo north=Bar\n"), nil))
+
+	// the limit of maps holds, a deleted map freeing its slot
+	few := NewServer(WithMaxMaps(1))
+	first := uploadMap(t, few, "../test_data/test_map")
+	require.Equal(t, http.StatusTooManyRequests, call(t, few, http.MethodPost, "/maps", strings.NewReader("Foo\n"), nil))
+	require.Equal(t, http.StatusNoContent, call(t, few, http.MethodDelete, "/maps/"+first.ID, nil, nil))
+	uploadMap(t, few, "../test_data/test_map")
+
+	require.Equal(t, http.StatusMethodNotAllowed, call(t, s, http.MethodPut, "/maps", nil, nil))
+	require.Equal(t, http.StatusNotFound, call(t, s, http.MethodGet, "/nowhere", nil, nil))
+}
+
+func Test_Server_FreeFormMaps(t *testing.T) {
+	s := NewServer()
+
+	// the free-form directions belong to the map, every map having room for its own
+	for _, prefix := range []string{"east", "west"} {
+		m := &Map{}
+		require.Equal(t, http.StatusCreated, call(t, s, http.MethodPost, "/maps?directions=free", freeFormMap(prefix, types.MaxFreeFormDirections), m))
+		require.Equal(t, "free", m.Directions)
+		require.Equal(t, http.StatusNoContent, call(t, s, http.MethodDelete, "/maps/"+m.ID, nil, nil))
+	}
+
+	failure := &ErrorResponse{}
+	require.Equal(t, http.StatusUnprocessableEntity, call(t, s, http.MethodPost, "/maps?directions=free", freeFormMap("north", types.MaxFreeFormDirections+1), failure))
+	require.Equal(t, types.ERR_TOO_MANY_DIRECTIONS.Error(), failure.Error)
+}
+
+func Test_Server_Simulation(t *testing.T) {
+	s := NewServer()
+	m := uploadMap(t, s, "../test_data/test_map2")
+
+	params := fmt.Sprintf(`{"map_id":%q,"aliens":50,"max_moves":200,"seed":42}`, m.ID)
+	created := createSimulation(t, s, params)
+	require.Equal(t, uint(0), created.Result.Steps)
+	require.Len(t, created.Result.Aliens, 50)
+	require.Equal(t, int64(42), *created.Params.Seed)
+	id := created.ID
+
+	// results are only available once finished
+	require.Equal(t, http.StatusConflict, call(t, s, http.MethodGet, "/simulations/"+id+"/results", nil, nil))
+
+	stepped := &SimulationState{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodPost, "/simulations/"+id+"/step?count=5", nil, stepped))
+	require.Equal(t, uint(5), stepped.Result.Steps)
+	require.False(t, stepped.Result.Finished)
+
+	stepped = &SimulationState{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodPost, "/simulations/"+id+"/step", nil, stepped))
+	require.Equal(t, uint(6), stepped.Result.Steps)
+
+	finished := &SimulationState{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodPost, "/simulations/"+id+"/run", nil, finished))
+	require.True(t, finished.Result.Finished)
+
+	current := &SimulationState{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, "/simulations/"+id, nil, current))
+	require.Equal(t, finished, current)
+
+	results := &SimulationResults{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, "/simulations/"+id+"/results", nil, results))
+	require.Contains(t, results.Report, "Simulation Finished")
+	require.Equal(t, finished.Result, results.Result)
+
+	// events are paged by offset
+	page := &EventPage{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, "/simulations/"+id+"/events?limit=10", nil, page))
+	require.Len(t, page.Events, 10)
+	require.Equal(t, int64(0), page.Offset)
+	require.Equal(t, int64(10), page.Next)
+	require.Equal(t, engine.EventLanded, page.Events[0].Type)
+
+	page = &EventPage{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, fmt.Sprintf("/simulations/%s/events?offset=%d", id, finished.NextEvent-1), nil, page))
+	require.Equal(t, []engine.Event{{Step: finished.Result.Steps, Type: engine.EventFinished, Reason: finished.Result.Termination}}, page.Events)
+
+	// the same seed replays the same invasion
+	replay := createSimulation(t, s, params)
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodPost, "/simulations/"+replay.ID+"/run", nil, nil))
+	replayResults := &SimulationResults{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, "/simulations/"+replay.ID+"/results", nil, replayResults))
+	require.Equal(t, results.Report, replayResults.Report)
+	require.Equal(t, results.Result, replayResults.Result)
+
+	var states []SimulationState
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, "/simulations", nil, &states))
+	require.Len(t, states, 2)
+
+	require.Equal(t, http.StatusNoContent, call(t, s, http.MethodDelete, "/simulations/"+replay.ID, nil, nil))
+	require.Equal(t, http.StatusNotFound, call(t, s, http.MethodGet, "/simulations/"+replay.ID, nil, nil))
+}
+
+func Test_Server_Fragmentation(t *testing.T) {
+	s := NewServer()
+	m := uploadMap(t, s, "../test_data/test_map2")
+	created := createSimulation(t, s, fmt.Sprintf(`{"map_id":%q,"aliens":300,"max_moves":100,"seed":3,"fragmentation":true}`, m.ID))
+	require.True(t, created.Params.Fragmentation)
+	require.Nil(t, created.Result.Fragmentation)
+
+	results := &SimulationResults{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodPost, "/simulations/"+created.ID+"/run", nil, nil))
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, "/simulations/"+created.ID+"/results", nil, results))
+	require.NotNil(t, results.Result.Fragmentation)
+	require.Equal(t, 625, results.Result.Fragmentation.LargestBefore)
+	require.Contains(t, results.Report, "Disconnected Pairs: ")
+}
+
+func Test_Server_SimulationErrors(t *testing.T) {
+	s := NewServer(WithMaxSimulations(1))
+	m := uploadMap(t, s, "../test_data/test_map")
+
+	require.Equal(t, http.StatusNotFound, call(t, s, http.MethodPost, "/simulations", strings.NewReader(`{"map_id":"nope"}`), nil))
+	require.Equal(t, http.StatusBadRequest, call(t, s, http.MethodPost, "/simulations", strings.NewReader(`{`), nil))
+	require.Equal(t, http.StatusBadRequest, call(t, s, http.MethodPost, "/simulations", strings.NewReader(fmt.Sprintf(`{"map_id":%q,"aliens":4000000000}`, m.ID)), nil))
+	require.Equal(t, http.StatusBadRequest, call(t, s, http.MethodPost, "/simulations", strings.NewReader(fmt.Sprintf(`{"map_id":%q,"max_moves":%d}`, m.ID, uint(MaxMovesLimit)+1)), nil))
+	require.Equal(t, http.StatusBadRequest, call(t, s, http.MethodPost, "/simulations", strings.NewReader(`{"map_id":"`+strings.Repeat("1", maxRequestSize)+`"}`), nil))
+
+	state := createSimulation(t, s, fmt.Sprintf(`{"map_id":%q}`, m.ID))
+	require.Equal(t, uint(DefaultAliens), state.Params.Aliens)
+	require.Equal(t, uint(DefaultMaxMoves), state.Params.MaxMoves)
+	require.NotNil(t, state.Params.Seed)
+
+	// the limit of simulations holds
+	require.Equal(t, http.StatusTooManyRequests, call(t, s, http.MethodPost, "/simulations", strings.NewReader(fmt.Sprintf(`{"map_id":%q}`, m.ID)), nil))
+
+	require.Equal(t, http.StatusBadRequest, call(t, s, http.MethodPost, "/simulations/"+state.ID+"/step?count=0", nil, nil))
+	require.Equal(t, http.StatusBadRequest, call(t, s, http.MethodPost, "/simulations/"+state.ID+"/run?timeout=soon", nil, nil))
+	require.Equal(t, http.StatusBadRequest, call(t, s, http.MethodGet, "/simulations/"+state.ID+"/events?offset=-1", nil, nil))
+	require.Equal(t, http.StatusNotFound, call(t, s, http.MethodGet, "/simulations/"+state.ID+"/nothing", nil, nil))
+	require.Equal(t, http.StatusNotFound, call(t, s, http.MethodPost, "/simulations/nope/run", nil, nil))
+}
+
+func Test_Server_Cancellation(t *testing.T) {
+	s := NewServer(WithMaxRunning(1))
+	m := uploadMap(t, s, "../test_data/test_map_circuit")
+	created := createSimulation(t, s, fmt.Sprintf(`{"map_id":%q,"aliens":2,"max_moves":100000000,"seed":1}`, m.ID))
+
+	// a run bounded by a timeout returns the steps reached
+	state := &SimulationState{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodPost, "/simulations/"+created.ID+"/run?timeout=20ms", nil, state))
+	require.False(t, state.Result.Finished)
+	require.NotZero(t, state.Result.Steps)
+	require.False(t, state.Running)
+
+	// the state is readable while the simulation runs
+	done := make(chan int)
+	go func() {
+		done <- call(t, s, http.MethodPost, "/simulations/"+created.ID+"/run?timeout=50ms", nil, nil)
+	}()
+	for i := 0; i < 10; i++ {
+		require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, "/simulations/"+created.ID, nil, nil))
+	}
+	require.Equal(t, http.StatusOK, <-done)
+
+	// a run whose request is cancelled stops
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	request := httptest.NewRequest(http.MethodPost, "/simulations/"+state.ID+"/run", nil).WithContext(ctx)
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	// a running simulation can't be run twice at once
+	simulation, err := s.lookupSimulation(state.ID)
+	require.NoError(t, err)
+	simulation.mu.Lock()
+	simulation.running = true
+	simulation.mu.Unlock()
+	require.Equal(t, http.StatusConflict, call(t, s, http.MethodPost, "/simulations/"+state.ID+"/step", nil, nil))
+	simulation.mu.Lock()
+	simulation.running = false
+	simulation.mu.Unlock()
+
+	// deleting a simulation stops its steps
+	require.Equal(t, http.StatusNoContent, call(t, s, http.MethodDelete, "/simulations/"+state.ID, nil, nil))
+	require.ErrorIs(t, simulation.Step(context.Background(), 0), ERR_SIMULATION_DELETED)
+}
+
+func Test_Server_Metrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	s := NewServer(WithMetrics(registry))
+	m := uploadMap(t, s, "../test_data/test_map2")
+	created := createSimulation(t, s, fmt.Sprintf(`{"map_id":%q,"aliens":10,"max_moves":20,"seed":1}`, m.ID))
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodPost, "/simulations/"+created.ID+"/run", nil, nil))
+
+	request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, metrics.ContentType, recorder.Header().Get("Content-Type"))
+	require.Contains(t, recorder.Body.String(), "\nalien_invasion_steps_total 20\n")
+	require.Contains(t, recorder.Body.String(), "\nalien_invasion_map_loads_total 1\n")
+
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, "/debug/vars", nil, &map[string]interface{}{}))
+	require.Equal(t, http.StatusMethodNotAllowed, call(t, s, http.MethodPost, "/metrics", nil, nil))
+
+	// without a registry, no metrics are served
+	require.Equal(t, http.StatusNotFound, call(t, NewServer(), http.MethodGet, "/metrics", nil, nil))
+}
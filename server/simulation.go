@@ -0,0 +1,274 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"alien-invasion-cc/engine"
+)
+
+const (
+	// DefaultAliens is the number of aliens of a simulation which doesn't set it
+	DefaultAliens = 5
+	// DefaultMaxMoves is the number of steps of a simulation which doesn't set it
+	DefaultMaxMoves = 10000
+	// MaxAliens is the largest number of aliens a simulation is created with
+	MaxAliens = 100000
+	// MaxMovesLimit is the largest maximum number of steps a simulation is created with
+	MaxMovesLimit = 1000000000
+)
+
+// SimulationParams Type definition, the parameters a simulation is created with
+type SimulationParams struct {
+	// ID of the map the simulation runs on
+	MapID string `json:"map_id"`
+	// Number of aliens
+	Aliens uint `json:"aliens"`
+	// Maximum number of steps
+	MaxMoves uint `json:"max_moves"`
+	// Seed of the simulation, drawn at creation when not given
+	Seed *int64 `json:"seed,omitempty"`
+	// Flag whether aliens crossing each other on a road fight
+	RoadFights bool `json:"road_fights,omitempty"`
+	// Flag whether the way back of one-way roads is created
+	TwoWay bool `json:"two_way,omitempty"`
+	// Flag whether the results report how the destruction split the map
+	Fragmentation bool `json:"fragmentation,omitempty"`
+}
+
+// SimulationState Type definition, the state of a simulation
+type SimulationState struct {
+	// Simulation ID
+	ID string `json:"id"`
+	// Parameters the simulation was created with
+	Params SimulationParams `json:"params"`
+	// Flag whether steps are being run
+	Running bool `json:"running"`
+	// Offset the next event will get
+	NextEvent int64 `json:"next_event"`
+	// Snapshot of the simulation
+	Result *engine.Result `json:"result"`
+}
+
+// SimulationResults Type definition, the outcome of a finished simulation
+type SimulationResults struct {
+	SimulationState
+	// Text output of the simulation, as printed by the command line
+	Report string `json:"report"`
+}
+
+// Simulation runs an invasion on its own engine and world
+type Simulation struct {
+	// Simulation ID
+	ID string
+
+	params SimulationParams
+
+	// mu guards the engine, the report and the flags
+	mu sync.Mutex
+
+	engine *engine.EngineImpl
+
+	report bytes.Buffer
+
+	running bool
+
+	finished bool
+
+	events *eventLog
+
+	// ctx is cancelled when the simulation is deleted
+	ctx context.Context
+
+	cancel context.CancelFunc
+}
+
+// newSimulation creates a simulation on a map and lands its aliens, reporting to metrics if any
+func newSimulation(ctx context.Context, id string, m *Map, params SimulationParams, maxEvents int, metrics *engine.Metrics) (*Simulation, error) {
+	if params.Aliens == 0 {
+		params.Aliens = DefaultAliens
+	}
+
+	if params.MaxMoves == 0 {
+		params.MaxMoves = DefaultMaxMoves
+	}
+
+	if params.Aliens > MaxAliens {
+		return nil, fmt.Errorf("%w: at most %d aliens are allowed", ERR_INVALID_REQUEST, MaxAliens)
+	}
+
+	if params.MaxMoves > MaxMovesLimit {
+		return nil, fmt.Errorf("%w: at most %d moves are allowed", ERR_INVALID_REQUEST, MaxMovesLimit)
+	}
+
+	if params.Seed == nil {
+		seed := time.Now().UnixNano()
+		params.Seed = &seed
+	}
+
+	s := &Simulation{
+		ID:     id,
+		params: params,
+		events: newEventLog(maxEvents),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.engine = engine.NewEngine(
+		params.Aliens,
+		params.MaxMoves,
+		m.open(),
+		&s.report,
+		engine.WithMapFormat(m.Format),
+		engine.WithDirectionProfile(m.directions),
+		engine.WithRoadFights(params.RoadFights),
+		engine.WithAutoReverseRoads(params.TwoWay),
+		engine.WithFragmentationReport(params.Fragmentation),
+		engine.WithSeed(*params.Seed),
+		engine.WithEventListener(s.events.append),
+		engine.WithMetrics(metrics),
+	)
+
+	err := s.engine.LoadEngine(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Step runs up to count steps, all the remaining ones when count is zero, until the context is done
+func (s *Simulation) Step(ctx context.Context, count uint) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return ERR_SIMULATION_BUSY
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+	}()
+
+	for i := uint(0); count == 0 || i < count; i++ {
+		if s.ctx.Err() != nil {
+			return ERR_SIMULATION_DELETED
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		done, err := s.step()
+		if err != nil && s.ctx.Err() != nil {
+			return ERR_SIMULATION_DELETED
+		}
+
+		if err != nil || done {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// step runs the next step, returns true once the simulation is over; the step runs under the context of
+// the simulation, a request ending between two steps only, so no step is left half done
+func (s *Simulation) step() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finished {
+		return true, nil
+	}
+
+	hasNextMove, err := s.engine.HasNextMove(s.ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if !hasNextMove {
+		s.finished = true
+		return true, s.engine.Finalize(s.ctx)
+	}
+
+	return false, s.engine.DoNextMove(s.ctx)
+}
+
+// HasNextMove checks if the simulation can run another step
+func (s *Simulation) HasNextMove(ctx context.Context) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finished {
+		return false, nil
+	}
+	return s.engine.HasNextMove(ctx)
+}
+
+// Finalize ends the simulation at the step it reached, if not over yet, and retrieves its outcome
+func (s *Simulation) Finalize(ctx context.Context) (*SimulationResults, error) {
+	s.mu.Lock()
+	if !s.finished {
+		s.finished = true
+		err := s.engine.Finalize(ctx)
+		if err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+	}
+	s.mu.Unlock()
+
+	return s.Results(ctx)
+}
+
+// State retrieves the state of the simulation
+func (s *Simulation) State(ctx context.Context) (*SimulationState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state(ctx)
+}
+
+// state retrieves the state of the simulation, the lock being held
+func (s *Simulation) state(ctx context.Context) (*SimulationState, error) {
+	result, err := s.engine.Result(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// a simulation finalized early is over too
+	result.Finished = result.Finished || s.finished
+
+	return &SimulationState{
+		ID:        s.ID,
+		Params:    s.params,
+		Running:   s.running,
+		NextEvent: s.events.next(),
+		Result:    result,
+	}, nil
+}
+
+// Results retrieves the outcome of the simulation, once finished
+func (s *Simulation) Results(ctx context.Context) (*SimulationResults, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.finished {
+		return nil, ERR_SIMULATION_NOT_FINISHED
+	}
+
+	state, err := s.state(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimulationResults{
+		SimulationState: *state,
+		Report:          s.report.String(),
+	}, nil
+}
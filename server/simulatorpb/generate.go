@@ -0,0 +1,4 @@
+// Package simulatorpb holds the messages and the gRPC stubs of the Simulator service, generated from simulator.proto
+package simulatorpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative simulator.proto
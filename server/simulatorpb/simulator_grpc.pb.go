@@ -0,0 +1,533 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: simulator.proto
+
+// Simulator runs alien invasions on the maps it is given, each simulation on its own engine and world.
+// It mirrors the engine: LoadEngine, HasNextMove, DoNextMove, Run and Finalize, plus queries on the world
+// and a stream of the events of a simulation.
+
+package simulatorpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Simulator_LoadEngine_FullMethodName       = "/alieninvasion.v1.Simulator/LoadEngine"
+	Simulator_HasNextMove_FullMethodName      = "/alieninvasion.v1.Simulator/HasNextMove"
+	Simulator_DoNextMove_FullMethodName       = "/alieninvasion.v1.Simulator/DoNextMove"
+	Simulator_Run_FullMethodName              = "/alieninvasion.v1.Simulator/Run"
+	Simulator_Finalize_FullMethodName         = "/alieninvasion.v1.Simulator/Finalize"
+	Simulator_GetState_FullMethodName         = "/alieninvasion.v1.Simulator/GetState"
+	Simulator_DeleteSimulation_FullMethodName = "/alieninvasion.v1.Simulator/DeleteSimulation"
+	Simulator_ListCities_FullMethodName       = "/alieninvasion.v1.Simulator/ListCities"
+	Simulator_GetCity_FullMethodName          = "/alieninvasion.v1.Simulator/GetCity"
+	Simulator_ListAliens_FullMethodName       = "/alieninvasion.v1.Simulator/ListAliens"
+	Simulator_Watch_FullMethodName            = "/alieninvasion.v1.Simulator/Watch"
+)
+
+// SimulatorClient is the client API for Simulator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SimulatorClient interface {
+	// LoadEngine creates a simulation on a map and lands its aliens
+	LoadEngine(ctx context.Context, in *LoadEngineRequest, opts ...grpc.CallOption) (*SimulationState, error)
+	// HasNextMove checks whether the simulation can run another step
+	HasNextMove(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*HasNextMoveResponse, error)
+	// DoNextMove runs the next step, finalizing the simulation once it is over
+	DoNextMove(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*SimulationState, error)
+	// Run runs the simulation to its end, or until the deadline of the call
+	Run(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*SimulationState, error)
+	// Finalize ends the simulation, at the step it reached, and retrieves its report
+	Finalize(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*FinalizeResponse, error)
+	// GetState retrieves the state of a simulation
+	GetState(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*SimulationState, error)
+	// DeleteSimulation deletes a simulation, stopping its steps and streams
+	DeleteSimulation(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*DeleteSimulationResponse, error)
+	// ListCities retrieves every city of the world, in map order
+	ListCities(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*ListCitiesResponse, error)
+	// GetCity retrieves a city of the world by name
+	GetCity(ctx context.Context, in *GetCityRequest, opts ...grpc.CallOption) (*City, error)
+	// ListAliens retrieves every alien, by ID
+	ListAliens(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*ListAliensResponse, error)
+	// Watch streams the events of a simulation from an offset, until it finishes
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Simulator_WatchClient, error)
+}
+
+type simulatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSimulatorClient(cc grpc.ClientConnInterface) SimulatorClient {
+	return &simulatorClient{cc}
+}
+
+func (c *simulatorClient) LoadEngine(ctx context.Context, in *LoadEngineRequest, opts ...grpc.CallOption) (*SimulationState, error) {
+	out := new(SimulationState)
+	err := c.cc.Invoke(ctx, Simulator_LoadEngine_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorClient) HasNextMove(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*HasNextMoveResponse, error) {
+	out := new(HasNextMoveResponse)
+	err := c.cc.Invoke(ctx, Simulator_HasNextMove_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorClient) DoNextMove(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*SimulationState, error) {
+	out := new(SimulationState)
+	err := c.cc.Invoke(ctx, Simulator_DoNextMove_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorClient) Run(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*SimulationState, error) {
+	out := new(SimulationState)
+	err := c.cc.Invoke(ctx, Simulator_Run_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorClient) Finalize(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*FinalizeResponse, error) {
+	out := new(FinalizeResponse)
+	err := c.cc.Invoke(ctx, Simulator_Finalize_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorClient) GetState(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*SimulationState, error) {
+	out := new(SimulationState)
+	err := c.cc.Invoke(ctx, Simulator_GetState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorClient) DeleteSimulation(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*DeleteSimulationResponse, error) {
+	out := new(DeleteSimulationResponse)
+	err := c.cc.Invoke(ctx, Simulator_DeleteSimulation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorClient) ListCities(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*ListCitiesResponse, error) {
+	out := new(ListCitiesResponse)
+	err := c.cc.Invoke(ctx, Simulator_ListCities_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorClient) GetCity(ctx context.Context, in *GetCityRequest, opts ...grpc.CallOption) (*City, error) {
+	out := new(City)
+	err := c.cc.Invoke(ctx, Simulator_GetCity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorClient) ListAliens(ctx context.Context, in *SimulationRequest, opts ...grpc.CallOption) (*ListAliensResponse, error) {
+	out := new(ListAliensResponse)
+	err := c.cc.Invoke(ctx, Simulator_ListAliens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulatorClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Simulator_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Simulator_ServiceDesc.Streams[0], Simulator_Watch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &simulatorWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Simulator_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type simulatorWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *simulatorWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SimulatorServer is the server API for Simulator service.
+// All implementations must embed UnimplementedSimulatorServer
+// for forward compatibility
+type SimulatorServer interface {
+	// LoadEngine creates a simulation on a map and lands its aliens
+	LoadEngine(context.Context, *LoadEngineRequest) (*SimulationState, error)
+	// HasNextMove checks whether the simulation can run another step
+	HasNextMove(context.Context, *SimulationRequest) (*HasNextMoveResponse, error)
+	// DoNextMove runs the next step, finalizing the simulation once it is over
+	DoNextMove(context.Context, *SimulationRequest) (*SimulationState, error)
+	// Run runs the simulation to its end, or until the deadline of the call
+	Run(context.Context, *SimulationRequest) (*SimulationState, error)
+	// Finalize ends the simulation, at the step it reached, and retrieves its report
+	Finalize(context.Context, *SimulationRequest) (*FinalizeResponse, error)
+	// GetState retrieves the state of a simulation
+	GetState(context.Context, *SimulationRequest) (*SimulationState, error)
+	// DeleteSimulation deletes a simulation, stopping its steps and streams
+	DeleteSimulation(context.Context, *SimulationRequest) (*DeleteSimulationResponse, error)
+	// ListCities retrieves every city of the world, in map order
+	ListCities(context.Context, *SimulationRequest) (*ListCitiesResponse, error)
+	// GetCity retrieves a city of the world by name
+	GetCity(context.Context, *GetCityRequest) (*City, error)
+	// ListAliens retrieves every alien, by ID
+	ListAliens(context.Context, *SimulationRequest) (*ListAliensResponse, error)
+	// Watch streams the events of a simulation from an offset, until it finishes
+	Watch(*WatchRequest, Simulator_WatchServer) error
+	mustEmbedUnimplementedSimulatorServer()
+}
+
+// UnimplementedSimulatorServer must be embedded to have forward compatible implementations.
+type UnimplementedSimulatorServer struct {
+}
+
+func (UnimplementedSimulatorServer) LoadEngine(context.Context, *LoadEngineRequest) (*SimulationState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadEngine not implemented")
+}
+func (UnimplementedSimulatorServer) HasNextMove(context.Context, *SimulationRequest) (*HasNextMoveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasNextMove not implemented")
+}
+func (UnimplementedSimulatorServer) DoNextMove(context.Context, *SimulationRequest) (*SimulationState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DoNextMove not implemented")
+}
+func (UnimplementedSimulatorServer) Run(context.Context, *SimulationRequest) (*SimulationState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Run not implemented")
+}
+func (UnimplementedSimulatorServer) Finalize(context.Context, *SimulationRequest) (*FinalizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Finalize not implemented")
+}
+func (UnimplementedSimulatorServer) GetState(context.Context, *SimulationRequest) (*SimulationState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetState not implemented")
+}
+func (UnimplementedSimulatorServer) DeleteSimulation(context.Context, *SimulationRequest) (*DeleteSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSimulation not implemented")
+}
+func (UnimplementedSimulatorServer) ListCities(context.Context, *SimulationRequest) (*ListCitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCities not implemented")
+}
+func (UnimplementedSimulatorServer) GetCity(context.Context, *GetCityRequest) (*City, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCity not implemented")
+}
+func (UnimplementedSimulatorServer) ListAliens(context.Context, *SimulationRequest) (*ListAliensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAliens not implemented")
+}
+func (UnimplementedSimulatorServer) Watch(*WatchRequest, Simulator_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedSimulatorServer) mustEmbedUnimplementedSimulatorServer() {}
+
+// UnsafeSimulatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SimulatorServer will
+// result in compilation errors.
+type UnsafeSimulatorServer interface {
+	mustEmbedUnimplementedSimulatorServer()
+}
+
+func RegisterSimulatorServer(s grpc.ServiceRegistrar, srv SimulatorServer) {
+	s.RegisterService(&Simulator_ServiceDesc, srv)
+}
+
+func _Simulator_LoadEngine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadEngineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServer).LoadEngine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Simulator_LoadEngine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServer).LoadEngine(ctx, req.(*LoadEngineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Simulator_HasNextMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServer).HasNextMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Simulator_HasNextMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServer).HasNextMove(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Simulator_DoNextMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServer).DoNextMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Simulator_DoNextMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServer).DoNextMove(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Simulator_Run_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServer).Run(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Simulator_Run_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServer).Run(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Simulator_Finalize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServer).Finalize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Simulator_Finalize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServer).Finalize(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Simulator_GetState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServer).GetState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Simulator_GetState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServer).GetState(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Simulator_DeleteSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServer).DeleteSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Simulator_DeleteSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServer).DeleteSimulation(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Simulator_ListCities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServer).ListCities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Simulator_ListCities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServer).ListCities(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Simulator_GetCity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServer).GetCity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Simulator_GetCity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServer).GetCity(ctx, req.(*GetCityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Simulator_ListAliens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulatorServer).ListAliens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Simulator_ListAliens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulatorServer).ListAliens(ctx, req.(*SimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Simulator_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimulatorServer).Watch(m, &simulatorWatchServer{stream})
+}
+
+type Simulator_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type simulatorWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *simulatorWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Simulator_ServiceDesc is the grpc.ServiceDesc for Simulator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Simulator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "alieninvasion.v1.Simulator",
+	HandlerType: (*SimulatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LoadEngine",
+			Handler:    _Simulator_LoadEngine_Handler,
+		},
+		{
+			MethodName: "HasNextMove",
+			Handler:    _Simulator_HasNextMove_Handler,
+		},
+		{
+			MethodName: "DoNextMove",
+			Handler:    _Simulator_DoNextMove_Handler,
+		},
+		{
+			MethodName: "Run",
+			Handler:    _Simulator_Run_Handler,
+		},
+		{
+			MethodName: "Finalize",
+			Handler:    _Simulator_Finalize_Handler,
+		},
+		{
+			MethodName: "GetState",
+			Handler:    _Simulator_GetState_Handler,
+		},
+		{
+			MethodName: "DeleteSimulation",
+			Handler:    _Simulator_DeleteSimulation_Handler,
+		},
+		{
+			MethodName: "ListCities",
+			Handler:    _Simulator_ListCities_Handler,
+		},
+		{
+			MethodName: "GetCity",
+			Handler:    _Simulator_GetCity_Handler,
+		},
+		{
+			MethodName: "ListAliens",
+			Handler:    _Simulator_ListAliens_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Simulator_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "simulator.proto",
+}
@@ -0,0 +1,299 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"alien-invasion-cc/engine"
+)
+
+const (
+	// streamBatch is the number of events a stream reads from the event log at once
+	streamBatch = 256
+	// DefaultKeepAlive is the interval between two keep-alive messages of an idle stream
+	DefaultKeepAlive = 15 * time.Second
+	// wsWriteTimeout bounds the time a WebSocket client takes to accept a message before being disconnected
+	wsWriteTimeout = 10 * time.Second
+)
+
+// StreamMessage Type definition, a message of a WebSocket event stream
+type StreamMessage struct {
+	// Offset of the event, or of the first event dropped
+	Offset int64 `json:"offset"`
+	// Event streamed
+	Event *engine.Event `json:"event,omitempty"`
+	// Number of events dropped from Offset on, because the client fell behind
+	Dropped int64 `json:"dropped,omitempty"`
+}
+
+// eventSink writes the events of a stream to a client
+type eventSink interface {
+	// send writes an event
+	send(offset int64, event engine.Event) error
+	// dropped reports events the client missed, dropped from the event log before being sent
+	dropped(offset, count int64) error
+	// keepAlive writes a message keeping an idle connection open
+	keepAlive() error
+	// flush sends the messages written so far
+	flush() error
+}
+
+// follow streams the events of the simulation from an offset to a sink, until the simulation
+// finishes, the context is done or the simulation is deleted; the engine never waits for the sink,
+// a client falling behind further than the event log misses the dropped events
+func (s *Simulation) follow(ctx context.Context, offset int64, sink eventSink, keepAlive time.Duration) error {
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	for {
+		events, first, next := s.events.since(offset, streamBatch)
+		if first > offset {
+			err := sink.dropped(offset, first-offset)
+			if err != nil {
+				return err
+			}
+		}
+
+		for i, event := range events {
+			err := sink.send(first+int64(i), event)
+			if err != nil {
+				return err
+			}
+
+			if event.Type == engine.EventFinished {
+				return sink.flush()
+			}
+		}
+		offset = next
+
+		if len(events) > 0 {
+			err := sink.flush()
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if s.events.over(offset) {
+			return sink.flush()
+		}
+
+		select {
+		case <-s.events.wait(offset):
+		case <-ticker.C:
+			err := sink.keepAlive()
+			if err == nil {
+				err = sink.flush()
+			}
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.ctx.Done():
+			return ERR_SIMULATION_DELETED
+		}
+	}
+}
+
+// sseSink writes events as Server-Sent Events, identified by their offset
+type sseSink struct {
+	w http.ResponseWriter
+
+	flusher http.Flusher
+}
+
+func (k *sseSink) send(offset int64, event engine.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(k.w, "id: %d\nevent: %s\ndata: %s\n\n", offset, event.Type, data)
+	return err
+}
+
+func (k *sseSink) dropped(offset, count int64) error {
+	_, err := fmt.Fprintf(k.w, "event: dropped\ndata: {\"offset\":%d,\"dropped\":%d}\n\n", offset, count)
+	return err
+}
+
+func (k *sseSink) keepAlive() error {
+	_, err := fmt.Fprint(k.w, ": keep-alive\n\n")
+	return err
+}
+
+func (k *sseSink) flush() error {
+	k.flusher.Flush()
+	return nil
+}
+
+// wsSink writes events as WebSocket JSON messages
+type wsSink struct {
+	conn *websocket.Conn
+}
+
+func (k *wsSink) write(message StreamMessage) error {
+	err := k.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if err != nil {
+		return err
+	}
+	return k.conn.WriteJSON(message)
+}
+
+func (k *wsSink) send(offset int64, event engine.Event) error {
+	return k.write(StreamMessage{Offset: offset, Event: &event})
+}
+
+func (k *wsSink) dropped(offset, count int64) error {
+	return k.write(StreamMessage{Offset: offset, Dropped: count})
+}
+
+func (k *wsSink) keepAlive() error {
+	return k.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+}
+
+func (k *wsSink) flush() error {
+	return nil
+}
+
+// streamOffset retrieves the offset a stream starts from: after the Last-Event-ID of a reconnecting
+// Server-Sent Events client, or ?offset=, 0 by default
+func streamOffset(r *http.Request) (int64, error) {
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		offset, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || offset < 0 {
+			return 0, ERR_INVALID_REQUEST
+		}
+		return offset + 1, nil
+	}
+
+	value := r.URL.Query().Get("offset")
+	if value == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || offset < 0 {
+		return 0, ERR_INVALID_REQUEST
+	}
+	return offset, nil
+}
+
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request, id string) {
+	simulation, err := s.lookupSimulation(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	offset, err := streamOffset(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, ERR_STREAMING_UNSUPPORTED)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx, cancel := s.streamContext(r.Context())
+	defer cancel()
+
+	// the response is under way, the end of the stream is all the client can see
+	_ = simulation.follow(ctx, offset, &sseSink{w: w, flusher: flusher}, s.keepAlive)
+}
+
+func (s *Server) watchEvents(w http.ResponseWriter, r *http.Request, id string) {
+	simulation, err := s.lookupSimulation(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	offset, err := streamOffset(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: s.checkOrigin,
+	}
+
+	// the upgrader answers the failed handshakes itself
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	// the client only sends control messages, reading them notices when it leaves
+	ctx, cancel := s.streamContext(r.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = simulation.follow(ctx, offset, &wsSink{conn: conn}, s.keepAlive)
+
+	closeCode, reason := websocket.CloseNormalClosure, "simulation finished"
+	if err != nil {
+		closeCode, reason = websocket.CloseGoingAway, err.Error()
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(closeCode, reason), time.Now().Add(wsWriteTimeout))
+}
+
+// CloseStreams ends the event streams, open or to come, so that shutting down doesn't wait for them
+func (s *Server) CloseStreams() {
+	s.closeStreams.Do(func() {
+		close(s.streamsClosed)
+	})
+}
+
+// streamContext derives the context of an event stream, done once the streams are closed
+func (s *Server) streamContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	go func() {
+		select {
+		case <-s.streamsClosed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// checkOrigin accepts the WebSocket handshakes from the same origin or from an allowed one
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || origin == "http://"+r.Host || origin == "https://"+r.Host {
+		return true
+	}
+
+	for _, allowed := range s.allowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"alien-invasion-cc/engine"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// sseMessage is a message read from a Server-Sent Events stream
+type sseMessage struct {
+	id    string
+	event string
+	data  string
+}
+
+// readSSE reads the messages of a Server-Sent Events stream until it ends, skipping the comments
+func readSSE(t *testing.T, response *http.Response) []sseMessage {
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	messages := []sseMessage{}
+	message := sseMessage{}
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if message != (sseMessage{}) {
+				messages = append(messages, message)
+			}
+			message = sseMessage{}
+		case strings.HasPrefix(line, "id: "):
+			message.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			message.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			message.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return messages
+}
+
+// openStream opens the Server-Sent Events stream of a simulation
+func openStream(t *testing.T, ctx context.Context, url, lastEventID string) *http.Response {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		request.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	return response
+}
+
+// allEvents retrieves every event of a simulation through the events endpoint
+func allEvents(t *testing.T, handler http.Handler, id string) []engine.Event {
+	page := &EventPage{}
+	require.Equal(t, http.StatusOK, call(t, handler, http.MethodGet, "/simulations/"+id+"/events", nil, page))
+	return page.Events
+}
+
+func Test_Server_Stream(t *testing.T) {
+	s := NewServer()
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	m := uploadMap(t, s, "../test_data/test_map2")
+	created := createSimulation(t, s, fmt.Sprintf(`{"map_id":%q,"aliens":20,"max_moves":300,"seed":7}`, m.ID))
+	url := server.URL + "/simulations/" + created.ID + "/stream"
+
+	// a stream opened before the simulation runs follows it until it finishes
+	response := openStream(t, context.Background(), url, "")
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodPost, "/simulations/"+created.ID+"/run", nil, nil))
+	messages := readSSE(t, response)
+
+	events := allEvents(t, s, created.ID)
+	require.Len(t, messages, len(events))
+	for i, message := range messages {
+		require.Equal(t, strconv.Itoa(i), message.id)
+		require.Equal(t, string(events[i].Type), message.event)
+
+		event := engine.Event{}
+		require.NoError(t, json.Unmarshal([]byte(message.data), &event))
+		require.Equal(t, events[i], event)
+	}
+	require.Equal(t, string(engine.EventFinished), messages[len(messages)-1].event)
+
+	// a reconnecting client resumes after the last event it received
+	resumed := openStream(t, context.Background(), url, "9")
+	defer resumed.Body.Close()
+	require.Equal(t, messages[10:], readSSE(t, resumed))
+
+	offset := openStream(t, context.Background(), url+"?offset=3", "")
+	defer offset.Body.Close()
+	require.Equal(t, messages[3:], readSSE(t, offset))
+
+	// nothing is left past the end of a finished simulation
+	past := openStream(t, context.Background(), fmt.Sprintf("%s?offset=%d", url, len(messages)), "")
+	defer past.Body.Close()
+	require.Empty(t, readSSE(t, past))
+
+	invalid := openStream(t, context.Background(), url, "later")
+	defer invalid.Body.Close()
+	require.Equal(t, http.StatusBadRequest, invalid.StatusCode)
+
+	missing := openStream(t, context.Background(), server.URL+"/simulations/nope/stream", "")
+	defer missing.Body.Close()
+	require.Equal(t, http.StatusNotFound, missing.StatusCode)
+}
+
+func Test_Server_StreamDropped(t *testing.T) {
+	s := NewServer(WithMaxEvents(8))
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	m := uploadMap(t, s, "../test_data/test_map2")
+	created := createSimulation(t, s, fmt.Sprintf(`{"map_id":%q,"aliens":20,"max_moves":300,"seed":7}`, m.ID))
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodPost, "/simulations/"+created.ID+"/run", nil, nil))
+
+	// a client behind the event log is told about the events it missed
+	response := openStream(t, context.Background(), server.URL+"/simulations/"+created.ID+"/stream", "")
+	defer response.Body.Close()
+	messages := readSSE(t, response)
+
+	page := &EventPage{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodGet, "/simulations/"+created.ID+"/events", nil, page))
+	require.NotZero(t, page.Offset)
+	require.Equal(t, sseMessage{event: "dropped", data: fmt.Sprintf(`{"offset":0,"dropped":%d}`, page.Offset)}, messages[0])
+	require.Len(t, messages, len(page.Events)+1)
+	require.Equal(t, strconv.FormatInt(page.Offset, 10), messages[1].id)
+}
+
+func Test_Server_StreamBackpressure(t *testing.T) {
+	s := NewServer(WithMaxEvents(16), WithKeepAlive(5*time.Millisecond))
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	m := uploadMap(t, s, "../test_data/test_map2")
+	created := createSimulation(t, s, fmt.Sprintf(`{"map_id":%q,"aliens":100,"max_moves":1000,"seed":3}`, m.ID))
+
+	// a client which never reads doesn't hold the simulation back
+	ctx, cancel := context.WithCancel(context.Background())
+	response := openStream(t, ctx, server.URL+"/simulations/"+created.ID+"/stream", "")
+	defer response.Body.Close()
+
+	state := &SimulationState{}
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodPost, "/simulations/"+created.ID+"/run", nil, state))
+	require.True(t, state.Result.Finished)
+	cancel()
+
+	// an idle stream is kept alive, and ends once the simulation is deleted
+	other := createSimulation(t, s, fmt.Sprintf(`{"map_id":%q,"aliens":1,"seed":3}`, m.ID))
+	idle := openStream(t, context.Background(), server.URL+"/simulations/"+other.ID+"/stream?offset=1", "")
+	defer idle.Body.Close()
+
+	reader := bufio.NewReader(idle.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, ": keep-alive\n", line)
+
+	require.Equal(t, http.StatusNoContent, call(t, s, http.MethodDelete, "/simulations/"+other.ID, nil, nil))
+	require.Empty(t, readSSE(t, &http.Response{StatusCode: http.StatusOK, Header: idle.Header, Body: idleBody{reader, idle}}))
+}
+
+// idleBody reads the rest of a response body through a buffered reader
+type idleBody struct {
+	*bufio.Reader
+	response *http.Response
+}
+
+func (b idleBody) Close() error {
+	return b.response.Body.Close()
+}
+
+func Test_Server_WebSocket(t *testing.T) {
+	s := NewServer(WithAllowedOrigins("https://viewer.example"))
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	m := uploadMap(t, s, "../test_data/test_map2")
+	created := createSimulation(t, s, fmt.Sprintf(`{"map_id":%q,"aliens":20,"max_moves":300,"seed":7}`, m.ID))
+	require.Equal(t, http.StatusOK, call(t, s, http.MethodPost, "/simulations/"+created.ID+"/run", nil, nil))
+	events := allEvents(t, s, created.ID)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/simulations/" + created.ID + "/ws"
+	header := http.Header{"Origin": []string{"https://viewer.example"}}
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?offset=5", header)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// the events are streamed from the offset on, then the connection is closed
+	for i := 5; i < len(events); i++ {
+		message := StreamMessage{}
+		require.NoError(t, conn.ReadJSON(&message))
+		require.Equal(t, int64(i), message.Offset)
+		require.Equal(t, events[i], *message.Event)
+	}
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), err)
+
+	// the handshakes from other origins are rejected
+	_, response, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": []string{"https://elsewhere.example"}})
+	require.Error(t, err)
+	require.Equal(t, http.StatusForbidden, response.StatusCode)
+
+	_, response, err = websocket.DefaultDialer.Dial(url+"?offset=-1", nil)
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
@@ -0,0 +1,91 @@
+package trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// FileExporter writes the spans as JSON lines, one span per line
+type FileExporter struct {
+	// mu guards the writer and the error
+	mu sync.Mutex
+
+	w *bufio.Writer
+
+	encoder *json.Encoder
+
+	err error
+}
+
+// Generate New FileExporter writing to w, buffered until flushed
+func NewFileExporter(w io.Writer) *FileExporter {
+	bw := bufio.NewWriter(w)
+	return &FileExporter{
+		w:       bw,
+		encoder: json.NewEncoder(bw),
+	}
+}
+
+// Export writes a span; the first write error is kept and reported by Flush
+func (e *FileExporter) Export(span SpanData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err != nil {
+		return
+	}
+	e.err = e.encoder.Encode(span)
+}
+
+// Flush writes the buffered spans, returns the first error met while exporting
+func (e *FileExporter) Flush() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err != nil {
+		return e.err
+	}
+	e.err = e.w.Flush()
+	return e.err
+}
+
+// InMemoryExporter keeps the spans in memory, for tests
+type InMemoryExporter struct {
+	// mu guards the spans
+	mu sync.Mutex
+
+	spans []SpanData
+}
+
+// Generate New InMemoryExporter
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{}
+}
+
+// Export keeps a span
+func (e *InMemoryExporter) Export(span SpanData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.spans = append(e.spans, span)
+}
+
+// Spans retrieves the spans exported so far, in the order they ended
+func (e *InMemoryExporter) Spans() []SpanData {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	spans := make([]SpanData, len(e.spans))
+	copy(spans, e.spans)
+	return spans
+}
+
+// Reset drops the spans exported so far
+func (e *InMemoryExporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.spans = nil
+}
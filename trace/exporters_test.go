@@ -0,0 +1,60 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func Test_FileExporter(t *testing.T) {
+	out := &bytes.Buffer{}
+	exporter := NewFileExporter(out)
+	ctx := ContextWithTracer(context.Background(), NewTracer(exporter))
+
+	for _, city := range []string{"Foo", "Bar"} {
+		_, span := Start(ctx, "World.DestroyCity", String("city", city))
+		span.End()
+	}
+
+	// the spans are buffered until flushed
+	require.Zero(t, out.Len())
+	require.NoError(t, exporter.Flush())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+
+	span := SpanData{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &span))
+	require.Equal(t, "World.DestroyCity", span.Name)
+	require.Equal(t, map[string]interface{}{"city": "Bar"}, span.Attributes)
+	require.Contains(t, lines[1], `"duration_ns":`)
+
+	failing := NewFileExporter(failingWriter{})
+	failing.Export(span)
+	require.EqualError(t, failing.Flush(), "disk full")
+}
+
+func Test_InMemoryExporter(t *testing.T) {
+	exporter := NewInMemoryExporter()
+	exporter.Export(SpanData{Name: "first"})
+
+	spans := exporter.Spans()
+	exporter.Export(SpanData{Name: "second"})
+	require.Len(t, spans, 1)
+	require.Len(t, exporter.Spans(), 2)
+
+	exporter.Reset()
+	require.Empty(t, exporter.Spans())
+}
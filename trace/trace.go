@@ -0,0 +1,167 @@
+package trace
+
+import (
+	"context"
+	"encoding/hex"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Attribute is a key and a value describing a span
+type Attribute struct {
+	Key string
+
+	Value interface{}
+}
+
+// String retrieves a string attribute
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int retrieves an int attribute
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Ints retrieves an int list attribute
+func Ints(key string, value []int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// SpanData Type definition, a finished span as exporters receive it
+type SpanData struct {
+	// ID of the trace the span belongs to, shared by a root span and its descendants
+	TraceID string `json:"trace_id"`
+	// Span ID
+	SpanID string `json:"span_id"`
+	// ID of the parent span, empty for a root span
+	ParentID string `json:"parent_id,omitempty"`
+	// Name of the operation
+	Name string `json:"name"`
+	// Start time
+	Start time.Time `json:"start"`
+	// End time
+	End time.Time `json:"end"`
+	// Duration in nanoseconds
+	Duration time.Duration `json:"duration_ns"`
+	// Attributes describing the operation
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	// Error the operation failed with
+	Error string `json:"error,omitempty"`
+}
+
+// Exporter receives the spans as they end
+type Exporter interface {
+	// Export exports a finished span
+	Export(span SpanData)
+}
+
+// Tracer starts spans and hands them to an exporter once ended
+type Tracer struct {
+	exporter Exporter
+
+	// mu guards the random source of the IDs
+	mu sync.Mutex
+
+	random *rand.Rand
+}
+
+// Generate New Tracer
+func NewTracer(exporter Exporter) *Tracer {
+	return &Tracer{
+		exporter: exporter,
+		random:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// newID draws a hex ID of a number of bytes
+func (t *Tracer) newID(size int) string {
+	id := make([]byte, size)
+
+	t.mu.Lock()
+	t.random.Read(id)
+	t.mu.Unlock()
+
+	return hex.EncodeToString(id)
+}
+
+// Span is an operation being traced; a nil span, started without a tracer, does nothing
+type Span struct {
+	tracer *Tracer
+
+	data SpanData
+}
+
+type tracerKey struct{}
+
+type spanKey struct{}
+
+// ContextWithTracer retrieves a context the spans started from are traced by a tracer
+func ContextWithTracer(ctx context.Context, tracer *Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// Start starts a span, child of the span of the context if any, when the context carries a tracer;
+// the context retrieved carries the span started
+func Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	tracer, _ := ctx.Value(tracerKey{}).(*Tracer)
+	if tracer == nil {
+		return ctx, nil
+	}
+
+	span := &Span{
+		tracer: tracer,
+		data: SpanData{
+			SpanID: tracer.newID(8),
+			Name:   name,
+			Start:  time.Now(),
+		},
+	}
+
+	if parent, _ := ctx.Value(spanKey{}).(*Span); parent != nil {
+		span.data.TraceID = parent.data.TraceID
+		span.data.ParentID = parent.data.SpanID
+	} else {
+		span.data.TraceID = tracer.newID(16)
+	}
+
+	span.SetAttributes(attributes...)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SetAttributes adds attributes to the span
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil || len(attributes) == 0 {
+		return
+	}
+
+	if s.data.Attributes == nil {
+		s.data.Attributes = make(map[string]interface{}, len(attributes))
+	}
+
+	for _, attribute := range attributes {
+		s.data.Attributes[attribute.Key] = attribute.Value
+	}
+}
+
+// RecordError records the error the operation failed with, if any
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.data.Error = err.Error()
+}
+
+// End ends the span and exports it
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.data.End = time.Now()
+	s.data.Duration = s.data.End.Sub(s.data.Start)
+	s.tracer.exporter.Export(s.data)
+}
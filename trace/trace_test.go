@@ -0,0 +1,54 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Start(t *testing.T) {
+	// without a tracer, spans do nothing
+	ctx, span := Start(context.Background(), "nothing", String("city", "Foo"))
+	require.Nil(t, span)
+	require.Equal(t, context.Background(), ctx)
+	span.SetAttributes(Int("step", 1))
+	span.RecordError(errors.New("failed"))
+	span.End()
+
+	exporter := NewInMemoryExporter()
+	ctx = ContextWithTracer(context.Background(), NewTracer(exporter))
+
+	rootCtx, root := Start(ctx, "root", Int("aliens", 2))
+	_, child := Start(rootCtx, "child", String("city", "Foo"), Ints("aliens", []int{1, 2}))
+	child.RecordError(errors.New("failed"))
+	child.End()
+	root.SetAttributes(Int("steps", 3))
+	root.End()
+
+	_, other := Start(ctx, "other")
+	other.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 3)
+
+	// spans are exported as they end, children first
+	require.Equal(t, "child", spans[0].Name)
+	require.Equal(t, "root", spans[1].Name)
+	require.Equal(t, spans[1].TraceID, spans[0].TraceID)
+	require.Equal(t, spans[1].SpanID, spans[0].ParentID)
+	require.Empty(t, spans[1].ParentID)
+	require.Len(t, spans[1].TraceID, 32)
+	require.Len(t, spans[1].SpanID, 16)
+
+	require.Equal(t, map[string]interface{}{"city": "Foo", "aliens": []int{1, 2}}, spans[0].Attributes)
+	require.Equal(t, "failed", spans[0].Error)
+	require.Equal(t, map[string]interface{}{"aliens": 2, "steps": 3}, spans[1].Attributes)
+	require.Equal(t, spans[1].End.Sub(spans[1].Start), spans[1].Duration)
+	require.False(t, spans[1].End.Before(spans[0].End))
+
+	// a new root span starts a new trace
+	require.NotEqual(t, spans[1].TraceID, spans[2].TraceID)
+	require.Empty(t, spans[2].ParentID)
+}